@@ -6,7 +6,9 @@ import (
 
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/httpx"
 	"hanbao-engine/app/hanbao/api/internal/config"
+	"hanbao-engine/app/hanbao/api/internal/errorx"
 	"hanbao-engine/app/hanbao/api/internal/handler"
 	"hanbao-engine/app/hanbao/api/internal/svc"
 )
@@ -19,10 +21,13 @@ func main() {
 	var c config.Config
 	conf.MustLoad(*configFile, &c)
 
-	server := rest.MustNewServer(c.RestConf)
+	server := rest.MustNewServer(c.RestConf, rest.WithCors())
 	defer server.Stop()
 
-	ctx := svc.NewServiceContext(c.RestConf)
+	httpx.SetErrorHandlerCtx(errorx.ErrorHandler)
+	server.Use(errorx.LanguageMiddleware)
+
+	ctx := svc.NewServiceContext(c)
 	handler.RegisterHandlers(server, ctx)
 
 	fmt.Printf("🚀 汉字寻宝引擎启动成功!\n")
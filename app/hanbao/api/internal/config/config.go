@@ -1,8 +1,77 @@
 package config
 
-import "github.com/zeromicro/go-zero/rest"
+import (
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/rest"
+)
 
 // Config 应用配置
 type Config struct {
 	rest.RestConf
+	Cache    cache.CacheConf `json:",optional"`
+	Content  ContentConf
+	Unlock   UnlockConf
+	Coverage CoverageConf `json:",optional"`
+	Session  SessionConf
+	Level    LevelConf
+	Review   ReviewConf
+	Adaptive AdaptiveConf
+}
+
+// ContentConf 内容存储配置
+type ContentConf struct {
+	Store      string `json:",default=memory,options=memory|sql"`            // 存储类型: memory 使用内置数据，sql 使用数据库
+	Driver     string `json:",default=sqlite,options=sqlite|mysql|postgres"` // 数据库驱动
+	DataSource string `json:",optional"`                                     // 数据源，如 file:hanbao.db
+	Pack       string `json:",optional"`                                     // 启动时加载的内容包（.json/.yaml 文件或CSV目录），为空时使用内置数据
+	Migrate    bool   `json:",default=true"`                                 // 启动时执行数据库迁移
+	Seed       bool   `json:",default=true"`                                 // 启动时将内容包同步到数据库
+}
+
+// UnlockConf 解锁仪式配置
+type UnlockConf struct {
+	MaxWords     int               `json:",default=5"`       // 每次解锁最多输入的词语数（每个输入项按识别出的词计数，至少为1）
+	ApiKeys      map[string]string `json:",optional"`        // API密钥对应的客户端类型，请求头 X-Api-Key 携带，如 <密钥>: teacher
+	Clients      map[string]int    `json:",optional"`        // 按客户端类型单独设置的上限，如 teacher: 200
+	BulkMaxWords int               `json:",default=5000"`    // 批量分析最多包含的词数（分词后）
+	BulkMaxBytes int64             `json:",default=1048576"` // 批量分析的请求体最大字节数，由处理函数检查，不能大于 MaxBytes
+}
+
+// CoverageConf 文本覆盖率估算配置
+type CoverageConf struct {
+	FrequencyLists map[string]string `json:",optional"` // 各语言的词频表文件，键为语言代码，如 ja: data/frequency/ja.txt
+}
+
+// SessionConf 会话存储配置
+type SessionConf struct {
+	Store      string `json:",default=memory,options=memory|redis|sql"` // 存储类型: redis 使用 Cache 配置
+	Driver     string `json:",optional,options=sqlite|mysql|postgres"`  // sql 存储的数据库驱动，为空时使用 Content 的配置
+	DataSource string `json:",optional"`                                // sql 存储的数据源，为空时使用 Content 的配置
+	Expire     int64  `json:",default=86400"`                           // 会话过期时间（秒）
+}
+
+// LevelConf 关卡答题记录存储配置
+type LevelConf struct {
+	Store  string `json:",default=memory,options=memory|redis|token"` // 存储类型: redis 使用 Cache 配置，token 为无状态的答案令牌模式
+	Expire int64  `json:",default=3600"`                              // 答题记录保存时间（秒），从第一次答题开始计算；token 模式下为答案令牌有效期
+	Secret string `json:",optional"`                                  // 关卡ID和答案令牌的签名密钥（至少16个字符），redis 和 token 模式下必填，memory 模式未配置时使用随机密钥
+}
+
+// ReviewConf 间隔重复复习配置
+type ReviewConf struct {
+	Store            string  `json:",default=memory,options=memory|sql"`      // 存储类型: sql 默认使用 Content 的数据库配置
+	Driver           string  `json:",optional,options=sqlite|mysql|postgres"` // sql 存储的数据库驱动，为空时使用 Content 的配置
+	DataSource       string  `json:",optional"`                               // sql 存储的数据源，为空时使用 Content 的配置
+	DesiredRetention float64 `json:",default=0.9,range=[0.7:0.99]"`           // 期望的记忆保持率
+	MaximumInterval  int     `json:",default=36500"`                          // 最长复习间隔（天）
+}
+
+// AdaptiveConf 自适应难度配置（Elo 能力模型）
+type AdaptiveConf struct {
+	Store          string  `json:",default=memory,options=memory|sql"`      // 存储类型: sql 默认使用 Content 的数据库配置
+	Driver         string  `json:",optional,options=sqlite|mysql|postgres"` // sql 存储的数据库驱动，为空时使用 Content 的配置
+	DataSource     string  `json:",optional"`                               // sql 存储的数据源，为空时使用 Content 的配置
+	KFactor        float64 `json:",default=32"`                             // 评分稳定后的K值
+	TargetSuccess  float64 `json:",default=0.7,range=[0.5:0.95]"`           // 选择关卡时期望的答对概率
+	DifficultyStep float64 `json:",default=100"`                            // 关卡难度每提高一级增加的题目评分
 }
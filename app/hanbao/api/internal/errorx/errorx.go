@@ -0,0 +1,105 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"hanbao-engine/pkg/hanbao"
+)
+
+// CodeError 接口错误响应
+type CodeError struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// statusByCode 错误码对应的HTTP状态码，未列出的错误码按 500 处理
+var statusByCode = map[string]int{
+	hanbao.CodeInvalidRequest:          http.StatusBadRequest,
+	hanbao.CodeNoWords:                 http.StatusBadRequest,
+	hanbao.CodeTooManyWords:            http.StatusBadRequest,
+	hanbao.CodeInputTooLarge:           http.StatusRequestEntityTooLarge,
+	hanbao.CodeInvalidLevelID:          http.StatusBadRequest,
+	hanbao.CodeLevelNotIssued:          http.StatusBadRequest,
+	hanbao.CodeUnsupportedLevelType:    http.StatusBadRequest,
+	hanbao.CodeAnswerTokenRequired:     http.StatusBadRequest,
+	hanbao.CodeAnswerTokenDisabled:     http.StatusBadRequest,
+	hanbao.CodeInvalidAnswerToken:      http.StatusBadRequest,
+	hanbao.CodeInvalidReviewGrade:      http.StatusBadRequest,
+	hanbao.CodeUnsupportedRomanization: http.StatusBadRequest,
+	hanbao.CodeContentNotFound:         http.StatusNotFound,
+	hanbao.CodeRootNotFound:            http.StatusNotFound,
+	hanbao.CodeLevelNotFound:           http.StatusNotFound,
+	hanbao.CodeQuestionNotFound:        http.StatusNotFound,
+	hanbao.CodeSessionNotFound:         http.StatusNotFound,
+	hanbao.CodeVocabularyNotFound:      http.StatusNotFound,
+	hanbao.CodeReviewCardNotFound:      http.StatusNotFound,
+	hanbao.CodeAnswerAlreadySubmitted:  http.StatusConflict,
+	hanbao.CodeAnswerTokenExpired:      http.StatusGone,
+	hanbao.CodeInsufficientVocabulary:  http.StatusUnprocessableEntity,
+	hanbao.CodeNoUnlockedRoots:         http.StatusUnprocessableEntity,
+}
+
+type langKey struct{}
+
+// NewInvalidRequest 包装请求解析错误，请求体超过大小上限时返回 INPUT_TOO_LARGE
+func NewInvalidRequest(err error) error {
+	if tooLarge := WrapBodyError(err); tooLarge != err {
+		return tooLarge
+	}
+	return hanbao.NewError(hanbao.CodeInvalidRequest, "请求参数错误").
+		WithDetails(map[string]any{"reason": err.Error()})
+}
+
+// WrapBodyError 包装读取请求体时的错误，请求体超过 http.MaxBytesReader 的上限时返回 INPUT_TOO_LARGE
+func WrapBodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return hanbao.ErrInputTooLarge.WithDetails(map[string]any{"max_bytes": tooLarge.Limit})
+	}
+	return err
+}
+
+// ErrorHandler 将错误转换为 {code, message, details} 响应，供 httpx.SetErrorHandlerCtx 使用
+func ErrorHandler(ctx context.Context, err error) (int, any) {
+	var e *hanbao.Error
+	if !errors.As(err, &e) {
+		logx.WithContext(ctx).Errorf("未处理的错误: %v", err)
+		e = hanbao.NewError(hanbao.CodeInternal, "服务器内部错误")
+	}
+
+	status, ok := statusByCode[e.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	return status, &CodeError{
+		Code:    e.Code,
+		Message: e.LocalizedMessage(langFromContext(ctx)),
+		Details: e.Details,
+	}
+}
+
+// LanguageMiddleware 从 Accept-Language 读取客户端首选语言，用于本地化错误消息
+func LanguageMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lang := r.Header.Get("Accept-Language")
+		if i := strings.IndexAny(lang, ",;"); i >= 0 {
+			lang = lang[:i]
+		}
+		if lang != "" {
+			r = r.WithContext(context.WithValue(r.Context(), langKey{}, lang))
+		}
+		next(w, r)
+	}
+}
+
+// langFromContext 获取请求的首选语言
+func langFromContext(ctx context.Context) string {
+	lang, _ := ctx.Value(langKey{}).(string)
+	return lang
+}
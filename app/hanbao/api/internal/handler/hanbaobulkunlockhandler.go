@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"hanbao-engine/app/hanbao/api/internal/errorx"
+	"hanbao-engine/app/hanbao/api/internal/logic"
+	"hanbao-engine/app/hanbao/api/internal/svc"
+	"hanbao-engine/app/hanbao/api/internal/types"
+)
+
+// HanbaoBulkUnlockHandler 批量解锁分析，支持 JSON 文本和 multipart 文件上传，
+// 请求头 Accept: text/event-stream 时以 SSE 流式返回分析事件
+func HanbaoBulkUnlockHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, svcCtx.Config.Unlock.BulkMaxBytes)
+
+		var req types.BulkUnlockRequest
+		var input io.Reader
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				httpx.ErrorCtx(r.Context(), w, errorx.NewInvalidRequest(err))
+				return
+			}
+			defer file.Close()
+			input = file
+			req.SessionID = r.FormValue("session_id")
+		} else if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, errorx.NewInvalidRequest(err))
+			return
+		}
+
+		l := logic.NewHanbaoBulkUnlockLogic(r.Context(), svcCtx)
+		if r.Header.Get("Accept") != "text/event-stream" {
+			resp, err := l.HanbaoBulkUnlock(&req, input, nil)
+			if err != nil {
+				httpx.ErrorCtx(r.Context(), w, errorx.WrapBodyError(err))
+			} else {
+				httpx.OkJsonCtx(r.Context(), w, resp)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		flusher, _ := w.(http.Flusher)
+		send := func(event string, data any) error {
+			body, err := json.Marshal(data)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, body); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
+		}
+
+		if _, err := l.HanbaoBulkUnlock(&req, input, func(event types.BulkUnlockEvent) error {
+			return send(event.Type, event)
+		}); err != nil {
+			// 流已经开始，错误作为 error 事件发送
+			_, body := errorx.ErrorHandler(r.Context(), errorx.WrapBodyError(err))
+			_ = send("error", body)
+		}
+	}
+}
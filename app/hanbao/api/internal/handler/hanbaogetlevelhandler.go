@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"hanbao-engine/app/hanbao/api/internal/errorx"
+	"hanbao-engine/app/hanbao/api/internal/logic"
+	"hanbao-engine/app/hanbao/api/internal/svc"
+	"hanbao-engine/app/hanbao/api/internal/types"
+)
+
+// HanbaoGetLevelHandler 获取关卡
+func HanbaoGetLevelHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.LevelRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, errorx.NewInvalidRequest(err))
+			return
+		}
+
+		l := logic.NewHanbaoGetLevelLogic(r.Context(), svcCtx)
+		resp, err := l.HanbaoGetLevel(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"hanbao-engine/app/hanbao/api/internal/errorx"
+	"hanbao-engine/app/hanbao/api/internal/logic"
+	"hanbao-engine/app/hanbao/api/internal/svc"
+	"hanbao-engine/app/hanbao/api/internal/types"
+)
+
+// HanbaoGetRecommendationsHandler 获取推荐
+func HanbaoGetRecommendationsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.RecommendationsRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, errorx.NewInvalidRequest(err))
+			return
+		}
+
+		l := logic.NewHanbaoGetRecommendationsLogic(r.Context(), svcCtx)
+		resp, err := l.HanbaoGetRecommendations(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
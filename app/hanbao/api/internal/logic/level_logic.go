@@ -1,9 +1,8 @@
 package logic
 
 import (
-	"errors"
-	"strconv"
-	"strings"
+	"context"
+	"time"
 
 	"github.com/zeromicro/go-zero/core/logx"
 	"hanbao-engine/app/hanbao/api/internal/svc"
@@ -11,35 +10,76 @@ import (
 	"hanbao-engine/pkg/hanbao"
 )
 
+// HanbaoListLevelTypesLogic 关卡类型列表逻辑
+type HanbaoListLevelTypesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoListLevelTypesLogic 创建关卡类型列表逻辑
+func NewHanbaoListLevelTypesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoListLevelTypesLogic {
+	return &HanbaoListLevelTypesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoListLevelTypes 列出已注册的关卡类型及可用字根
+func (l *HanbaoListLevelTypesLogic) HanbaoListLevelTypes() (resp *types.LevelTypesResponse, err error) {
+	levelTypes, err := l.svcCtx.LevelService.ListLevelTypes()
+	if err != nil {
+		l.Error("获取关卡类型失败: ", err)
+		return nil, err
+	}
+
+	resp = &types.LevelTypesResponse{
+		LevelTypes: make([]types.LevelType, len(levelTypes)),
+	}
+	for i, info := range levelTypes {
+		resp.LevelTypes[i] = types.LevelType{
+			Type:           info.Type,
+			Aliases:        append([]string{}, info.Aliases...),
+			Title:          info.Title,
+			Icon:           info.Icon,
+			Description:    info.Description,
+			TimeLimit:      info.TimeLimit,
+			Score:          info.Score,
+			RequiredData:   info.RequiredData,
+			AvailableRoots: info.AvailableRoots,
+		}
+	}
+
+	return resp, nil
+}
+
 // HanbaoGetLevelLogic 获取关卡逻辑
 type HanbaoGetLevelLogic struct {
 	logx.Logger
-	ctx    *svc.ServiceContext
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
 }
 
 // NewHanbaoGetLevelLogic 创建关卡获取逻辑
-func NewHanbaoGetLevelLogic(ctx *svc.ServiceContext) *HanbaoGetLevelLogic {
+func NewHanbaoGetLevelLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoGetLevelLogic {
 	return &HanbaoGetLevelLogic{
-		Logger: logx.WithContext(nil),
+		Logger: logx.WithContext(ctx),
 		ctx:    ctx,
+		svcCtx: svcCtx,
 	}
 }
 
 // HanbaoGetLevel 获取关卡
 func (l *HanbaoGetLevelLogic) HanbaoGetLevel(req *types.LevelRequest) (resp *types.Level, err error) {
-	// 解析关卡参数，格式: type_rootId_difficulty
-	// 示例: pron_1_1 (音读破译室，字根1，难度1)
-
-	parts := strings.Split(req.LevelId, "_")
-	if len(parts) != 3 {
-		return nil, errors.New("无效的关卡ID格式")
+	// 解析关卡参数，格式: type_rootId_difficulty_seed，省略种子时随机生成
+	// 示例: pron_1_1_2s9x (音读破译室，字根1，难度1，种子2s9x)
+	key, err := hanbao.ParseLevelID(req.LevelId)
+	if err != nil {
+		return nil, err
 	}
 
-	levelType := parts[0]
-	rootID, _ := strconv.ParseInt(parts[1], 10, 64)
-	difficulty, _ := strconv.Atoi(parts[2])
-
-	level, err := l.ctx.LevelService.GenerateLevel(levelType, rootID, difficulty)
+	level, err := l.svcCtx.LevelService.GenerateLevelFromKey(key)
 	if err != nil {
 		l.Error("生成关卡失败: ", err)
 		return nil, err
@@ -49,38 +89,107 @@ func (l *HanbaoGetLevelLogic) HanbaoGetLevel(req *types.LevelRequest) (resp *typ
 	return resp, nil
 }
 
+// HanbaoNextLevelLogic 自适应选择下一关逻辑
+type HanbaoNextLevelLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoNextLevelLogic 创建自适应选择下一关逻辑
+func NewHanbaoNextLevelLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoNextLevelLogic {
+	return &HanbaoNextLevelLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoNextLevel 根据学习者的能力评分，在已解锁的字根中选择关卡类型、字根和难度并生成关卡
+func (l *HanbaoNextLevelLogic) HanbaoNextLevel(req *types.NextLevelRequest) (resp *types.Level, err error) {
+	session, err := l.svcCtx.SessionService.GetSession(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := l.svcCtx.AdaptiveService.NextLevel(session.LearnerID(), session.UnlockedRoots)
+	if err != nil {
+		l.Error("生成下一关失败: ", err)
+		return nil, err
+	}
+
+	return convertLevel(*level), nil
+}
+
 // HanbaoAnswerLevelLogic 关卡答题逻辑
 type HanbaoAnswerLevelLogic struct {
 	logx.Logger
-	ctx    *svc.ServiceContext
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
 }
 
 // NewHanbaoAnswerLevelLogic 创建答题逻辑
-func NewHanbaoAnswerLevelLogic(ctx *svc.ServiceContext) *HanbaoAnswerLevelLogic {
+func NewHanbaoAnswerLevelLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoAnswerLevelLogic {
 	return &HanbaoAnswerLevelLogic{
-		Logger: logx.WithContext(nil),
+		Logger: logx.WithContext(ctx),
 		ctx:    ctx,
+		svcCtx: svcCtx,
 	}
 }
 
 // HanbaoAnswerLevel 提交答案
 func (l *HanbaoAnswerLevelLogic) HanbaoAnswerLevel(req *types.AnswerRequest) (resp *types.AnswerResult, err error) {
-	// 注意：这里的req是AnswerRequest，LevelId需要从路径参数获取
-	// 暂时使用示例关卡ID
-	levelId := "example"
+	levelId := req.LevelId
 	l.Info("关卡答题: ", levelId, " 问题: ", req.QuestionID)
 
-	result, err := l.ctx.LevelService.ValidateAnswer(levelId, req.QuestionID, req.Answer)
+	// 先确认会话存在，会话过期或ID错误时不消耗答题机会
+	if req.SessionID != "" {
+		if _, err := l.svcCtx.SessionService.GetSession(req.SessionID); err != nil {
+			l.Error("获取会话失败: ", err)
+			return nil, err
+		}
+	}
+
+	var result *hanbao.AnswerResult
+	if req.AnswerToken != "" {
+		result, err = l.svcCtx.LevelService.ValidateAnswerWithToken(req.AnswerToken, levelId, req.SessionID, req.QuestionID, req.Answer)
+	} else {
+		result, err = l.svcCtx.LevelService.ValidateAnswer(levelId, req.SessionID, req.QuestionID, req.Answer)
+	}
 	if err != nil {
 		l.Error("答案验证失败: ", err)
 		return nil, err
 	}
 
+	if req.SessionID != "" {
+		// 同一会话中每个问题只计分一次
+		session, err := l.svcCtx.SessionService.RecordAnswer(req.SessionID, req.QuestionID, result)
+		if err != nil {
+			l.Error("记录答题进度失败: ", err)
+			return nil, err
+		}
+
+		// 答题结果计入相关词汇的复习记录
+		if err := l.svcCtx.ReviewService.RecordAnswer(session.LearnerID(), result.VocabularyIDs, result.Correct, time.Now()); err != nil {
+			l.Error("记录复习结果失败: ", err)
+			return nil, err
+		}
+
+		// 答题结果计入能力评分，用于选择下一关的难度
+		if err := l.svcCtx.AdaptiveService.RecordAnswer(session.LearnerID(), result, time.Now()); err != nil {
+			l.Error("更新能力评分失败: ", err)
+			return nil, err
+		}
+	}
+
 	resp = &types.AnswerResult{
 		Correct:     result.Correct,
 		Score:       result.Score,
+		CorrectAnswer: result.CorrectAnswer,
 		Explanation: result.Explanation,
 		NextHint:    result.NextHint,
+		LevelCompleted: result.LevelCompleted,
+		SoundRules:  convertSoundRules(result.SoundRules),
 	}
 
 	return resp, nil
@@ -98,10 +207,11 @@ func convertLevel(level hanbao.Level) *types.Level {
 		TimeLimit:   level.TimeLimit,
 		Questions:   convertQuestions(level.Questions),
 		Reward:      convertReward(level.Reward),
+		AnswerToken: level.AnswerToken,
 	}
 }
 
-// convertQuestions 转换问题格式
+// convertQuestions 转换为公开的问题视图，不下发标准答案和解释
 func convertQuestions(questions []hanbao.Question) []types.Question {
 	result := make([]types.Question, len(questions))
 	for i, q := range questions {
@@ -110,14 +220,27 @@ func convertQuestions(questions []hanbao.Question) []types.Question {
 			Type:         q.Type,
 			Content:      q.Content,
 			Options:      q.Options,
-			CorrectAnswer: q.CorrectAnswer,
+			Matching:     convertMatchingGrid(q.Matching),
 			Hint:         q.Hint,
-			Explanation:  q.Explanation,
+			Score:        q.Score,
 		}
 	}
 	return result
 }
 
+// convertMatchingGrid 转换连线题的网格
+func convertMatchingGrid(grid *hanbao.MatchingGrid) *types.MatchingGrid {
+	if grid == nil {
+		return nil
+	}
+
+	result := &types.MatchingGrid{Rows: grid.Rows, Columns: make([]types.MatchingColumn, len(grid.Columns))}
+	for i, column := range grid.Columns {
+		result.Columns[i] = types.MatchingColumn{Label: column.Label, Options: column.Options}
+	}
+	return result
+}
+
 // convertReward 转换奖励格式
 func convertReward(reward hanbao.Reward) types.Reward {
 	return types.Reward{
@@ -0,0 +1,145 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"hanbao-engine/app/hanbao/api/internal/svc"
+	"hanbao-engine/app/hanbao/api/internal/types"
+	"hanbao-engine/pkg/hanbao"
+)
+
+// HanbaoDueReviewsLogic 待复习词汇逻辑
+type HanbaoDueReviewsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoDueReviewsLogic 创建待复习词汇逻辑
+func NewHanbaoDueReviewsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoDueReviewsLogic {
+	return &HanbaoDueReviewsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoDueReviews 获取已到期的复习
+func (l *HanbaoDueReviewsLogic) HanbaoDueReviews(req *types.DueReviewsRequest) (resp *types.DueReviewsResponse, err error) {
+	session, err := l.svcCtx.SessionService.GetSession(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := l.svcCtx.ReviewService.DueReviews(session.LearnerID(), time.Now(), req.Limit)
+	if err != nil {
+		l.Error("获取待复习词汇失败: ", err)
+		return nil, err
+	}
+
+	resp = &types.DueReviewsResponse{
+		Reviews: make([]types.DueReview, len(reviews)),
+	}
+	for i, review := range reviews {
+		resp.Reviews[i] = types.DueReview{
+			Card:       convertReviewCard(review.Card),
+			Vocabulary: convertVocabulary(review.Vocabulary),
+		}
+	}
+
+	return resp, nil
+}
+
+// HanbaoSubmitReviewLogic 提交复习评分逻辑
+type HanbaoSubmitReviewLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoSubmitReviewLogic 创建提交复习评分逻辑
+func NewHanbaoSubmitReviewLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoSubmitReviewLogic {
+	return &HanbaoSubmitReviewLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoSubmitReview 提交复习评分，返回更新后的卡片
+func (l *HanbaoSubmitReviewLogic) HanbaoSubmitReview(req *types.SubmitReviewRequest) (resp *types.ReviewCard, err error) {
+	session, err := l.svcCtx.SessionService.GetSession(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := l.svcCtx.ReviewService.Grade(session.LearnerID(), req.VocabularyID,
+		hanbao.ReviewGrade(req.Grade), time.Now())
+	if err != nil {
+		l.Error("提交复习评分失败: ", err)
+		return nil, err
+	}
+
+	result := convertReviewCard(*card)
+	return &result, nil
+}
+
+// HanbaoReviewForecastLogic 复习量预测逻辑
+type HanbaoReviewForecastLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoReviewForecastLogic 创建复习量预测逻辑
+func NewHanbaoReviewForecastLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoReviewForecastLogic {
+	return &HanbaoReviewForecastLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoReviewForecast 预测未来每天的复习量
+func (l *HanbaoReviewForecastLogic) HanbaoReviewForecast(req *types.ReviewForecastRequest) (resp *types.ReviewForecastResponse, err error) {
+	session, err := l.svcCtx.SessionService.GetSession(req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := l.svcCtx.ReviewService.Forecast(session.LearnerID(), time.Now(), req.Days)
+	if err != nil {
+		l.Error("预测复习量失败: ", err)
+		return nil, err
+	}
+
+	resp = &types.ReviewForecastResponse{
+		Days: make([]types.ReviewForecastDay, len(forecast)),
+	}
+	for i, day := range forecast {
+		resp.Days[i] = types.ReviewForecastDay{
+			Date:  day.Date,
+			Count: day.Count,
+		}
+	}
+
+	return resp, nil
+}
+
+// convertReviewCard 转换复习卡片格式
+func convertReviewCard(card hanbao.ReviewCard) types.ReviewCard {
+	result := types.ReviewCard{
+		VocabularyID: card.VocabularyID,
+		Stability:    card.Stability,
+		Difficulty:   card.Difficulty,
+		Reps:         card.Reps,
+		Lapses:       card.Lapses,
+		Due:          card.Due.Format(time.RFC3339),
+	}
+	if !card.LastReview.IsZero() {
+		result.LastReview = card.LastReview.Format(time.RFC3339)
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"hanbao-engine/app/hanbao/api/internal/svc"
+	"hanbao-engine/app/hanbao/api/internal/types"
+	"hanbao-engine/pkg/hanbao"
+)
+
+// HanbaoRomanizeLogic 罗马字转写逻辑
+type HanbaoRomanizeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoRomanizeLogic 创建罗马字转写逻辑
+func NewHanbaoRomanizeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoRomanizeLogic {
+	return &HanbaoRomanizeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoRomanize 把假名或谚文转写成罗马字
+func (l *HanbaoRomanizeLogic) HanbaoRomanize(req *types.RomanizeRequest) (resp *types.RomanizeResponse, err error) {
+	result, err := hanbao.Romanize(req.Text, req.Language, hanbao.RomanizeOptions{
+		Scheme:  req.Scheme,
+		Macrons: req.Macrons,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RomanizeResponse{
+		Language:  result.Language,
+		Scheme:    result.Scheme,
+		Text:      result.Text,
+		Romanized: result.Romanized,
+		Syllables: result.Syllables,
+		Unknown:   result.Unknown,
+	}, nil
+}
@@ -1,9 +1,9 @@
 package logic
 
 import (
+	"context"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
 	"hanbao-engine/app/hanbao/api/internal/svc"
 	"hanbao-engine/app/hanbao/api/internal/types"
@@ -12,28 +12,33 @@ import (
 // HanbaoStartSessionLogic 会话开始逻辑
 type HanbaoStartSessionLogic struct {
 	logx.Logger
-	ctx    *svc.ServiceContext
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
 }
 
 // NewHanbaoStartSessionLogic 创建会话逻辑
-func NewHanbaoStartSessionLogic(ctx *svc.ServiceContext) *HanbaoStartSessionLogic {
+func NewHanbaoStartSessionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoStartSessionLogic {
 	return &HanbaoStartSessionLogic{
-		Logger: logx.WithContext(nil),
+		Logger: logx.WithContext(ctx),
 		ctx:    ctx,
+		svcCtx: svcCtx,
 	}
 }
 
 // HanbaoStartSession 开始新会话
 func (l *HanbaoStartSessionLogic) HanbaoStartSession(req *types.StartSessionRequest) (resp *types.StartSessionResponse, err error) {
-	sessionID := uuid.New().String()
-	startTime := time.Now()
+	session, err := l.svcCtx.SessionService.StartSession(req.UserID)
+	if err != nil {
+		l.Error("创建会话失败: ", err)
+		return nil, err
+	}
 
-	l.Info("创建新会话: ", sessionID, " 用户: ", req.UserID)
+	l.Info("创建新会话: ", session.ID, " 用户: ", req.UserID)
 
 	resp = &types.StartSessionResponse{
-		SessionID: sessionID,
-		StartTime: startTime.Format(time.RFC3339),
-		Status:    "active",
+		SessionID: session.ID,
+		StartTime: session.StartTime.Format(time.RFC3339),
+		Status:    session.Status,
 		Message:   "汉字寻宝之旅开始！请先进行词根解锁仪式。",
 	}
 
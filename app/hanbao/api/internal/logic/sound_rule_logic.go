@@ -0,0 +1,80 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"hanbao-engine/app/hanbao/api/internal/svc"
+	"hanbao-engine/app/hanbao/api/internal/types"
+	"hanbao-engine/pkg/hanbao"
+)
+
+// HanbaoRootReadingsLogic 字根读音与对应规律逻辑
+type HanbaoRootReadingsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoRootReadingsLogic 创建字根读音与对应规律逻辑
+func NewHanbaoRootReadingsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoRootReadingsLogic {
+	return &HanbaoRootReadingsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoRootReadings 按读音对应规律推测字根的韩语、日语读音，并检验词汇中的实际读音
+func (l *HanbaoRootReadingsLogic) HanbaoRootReadings(req *types.RootReadingsRequest) (resp *types.RootReadingsResponse, err error) {
+	report, err := hanbao.AnalyzeRootReadings(l.svcCtx.ContentRepo, req.RootID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &types.RootReadingsResponse{
+		Root:        convertCharacterRoots([]hanbao.CharacterRoot{report.Root})[0],
+		Predictions: make([]types.ReadingPrediction, len(report.Predictions)),
+		Readings:    make([]types.RootReading, len(report.Readings)),
+	}
+	for i, p := range report.Predictions {
+		resp.Predictions[i] = types.ReadingPrediction{
+			Language: p.Language,
+			Source:   p.Source,
+			Pattern:  p.Pattern,
+			Rules:    convertSoundRules(p.Rules),
+		}
+	}
+	for i, r := range report.Readings {
+		resp.Readings[i] = types.RootReading{
+			Language:     r.Language,
+			Reading:      r.Reading,
+			VocabularyID: r.VocabularyID,
+			Word:         r.Word,
+			Score:        r.Score,
+			Explanation:  r.Explanation,
+		}
+	}
+
+	return resp, nil
+}
+
+// convertSoundRules 转换读音对应规律格式
+func convertSoundRules(rules []hanbao.SoundRule) []types.SoundRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]types.SoundRule, len(rules))
+	for i, rule := range rules {
+		result[i] = types.SoundRule{
+			ID:       rule.ID,
+			Part:     rule.Part,
+			From:     rule.From,
+			Korean:   rule.Korean,
+			Japanese: rule.Japanese,
+			Note:     rule.Note,
+			Example:  rule.Example,
+		}
+	}
+	return result
+}
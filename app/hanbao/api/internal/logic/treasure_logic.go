@@ -1,6 +1,7 @@
 package logic
 
 import (
+	"context"
 	"github.com/zeromicro/go-zero/core/logx"
 	"hanbao-engine/app/hanbao/api/internal/svc"
 	"hanbao-engine/app/hanbao/api/internal/types"
@@ -10,14 +11,16 @@ import (
 // HanbaoGetTreasureMapLogic 获取藏宝图逻辑
 type HanbaoGetTreasureMapLogic struct {
 	logx.Logger
-	ctx    *svc.ServiceContext
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
 }
 
 // NewHanbaoGetTreasureMapLogic 创建藏宝图逻辑
-func NewHanbaoGetTreasureMapLogic(ctx *svc.ServiceContext) *HanbaoGetTreasureMapLogic {
+func NewHanbaoGetTreasureMapLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoGetTreasureMapLogic {
 	return &HanbaoGetTreasureMapLogic{
-		Logger: logx.WithContext(nil),
+		Logger: logx.WithContext(ctx),
 		ctx:    ctx,
+		svcCtx: svcCtx,
 	}
 }
 
@@ -25,10 +28,13 @@ func NewHanbaoGetTreasureMapLogic(ctx *svc.ServiceContext) *HanbaoGetTreasureMap
 func (l *HanbaoGetTreasureMapLogic) HanbaoGetTreasureMap(req *types.TreasureMapRequest) (resp *types.TreasureMap, err error) {
 	l.Info("获取藏宝图: ", req.SessionID)
 
-	// 示例：假设用户已解锁前5个字根
-	unlockedRoots := []int64{1, 2, 3, 4, 5}
+	session, err := l.svcCtx.SessionService.GetSession(req.SessionID)
+	if err != nil {
+		l.Error("获取会话失败: ", err)
+		return nil, err
+	}
 
-	treasureMap, err := l.ctx.TreasureMapService.GenerateTreasureMap(req.SessionID, unlockedRoots)
+	treasureMap, err := l.svcCtx.TreasureMapService.GenerateTreasureMap(session)
 	if err != nil {
 		l.Error("生成藏宝图失败: ", err)
 		return nil, err
@@ -41,14 +47,16 @@ func (l *HanbaoGetTreasureMapLogic) HanbaoGetTreasureMap(req *types.TreasureMapR
 // HanbaoGetRecommendationsLogic 获取推荐逻辑
 type HanbaoGetRecommendationsLogic struct {
 	logx.Logger
-	ctx    *svc.ServiceContext
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
 }
 
 // NewHanbaoGetRecommendationsLogic 创建推荐逻辑
-func NewHanbaoGetRecommendationsLogic(ctx *svc.ServiceContext) *HanbaoGetRecommendationsLogic {
+func NewHanbaoGetRecommendationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoGetRecommendationsLogic {
 	return &HanbaoGetRecommendationsLogic{
-		Logger: logx.WithContext(nil),
+		Logger: logx.WithContext(ctx),
 		ctx:    ctx,
+		svcCtx: svcCtx,
 	}
 }
 
@@ -56,10 +64,17 @@ func NewHanbaoGetRecommendationsLogic(ctx *svc.ServiceContext) *HanbaoGetRecomme
 func (l *HanbaoGetRecommendationsLogic) HanbaoGetRecommendations(req *types.RecommendationsRequest) (resp *types.RecommendationsResponse, err error) {
 	l.Info("获取推荐: ", req.SessionID)
 
-	// 示例：当前已解锁的字根
-	currentRoots := []int64{1, 2, 3, 4, 5}
+	session, err := l.svcCtx.SessionService.GetSession(req.SessionID)
+	if err != nil {
+		l.Error("获取会话失败: ", err)
+		return nil, err
+	}
 
-	recommendations := l.ctx.TreasureMapService.GetNextRecommendations(currentRoots)
+	recommendations, err := l.svcCtx.TreasureMapService.GetNextRecommendations(session.UnlockedRoots)
+	if err != nil {
+		l.Error("获取推荐失败: ", err)
+		return nil, err
+	}
 
 	resp = &types.RecommendationsResponse{
 		RecommendedRoots: convertCharacterRootsForRecommendations(recommendations),
@@ -93,18 +108,42 @@ func convertVocabularies(vocabMap map[string][]hanbao.Vocabulary) map[string][]t
 	for key, vocabs := range vocabMap {
 		result[key] = make([]types.Vocabulary, len(vocabs))
 		for i, vocab := range vocabs {
-			result[key][i] = types.Vocabulary{
-				ID:            vocab.ID,
-				RootID:        vocab.RootID,
-				Language:      vocab.Language,
-				Word:          vocab.Word,
-				Romaji:        vocab.Romaji,
-				Pronunciation: vocab.Pronunciation,
-				Meaning:       vocab.Meaning,
-				ReadType:      vocab.ReadType,
-				Difficulty:    vocab.Difficulty,
-				ExampleCount:  vocab.ExampleCount,
-			}
+			result[key][i] = convertVocabulary(vocab)
+		}
+	}
+	return result
+}
+
+// convertVocabulary 转换单个词汇格式
+func convertVocabulary(vocab hanbao.Vocabulary) types.Vocabulary {
+	return types.Vocabulary{
+		ID:            vocab.ID,
+		RootID:        vocab.RootID,
+		Language:      vocab.Language,
+		Word:          vocab.Word,
+		Romaji:        vocab.Romaji,
+		Hanja:         vocab.Hanja,
+		Chinese:       vocab.Chinese,
+		Morphemes:     vocab.Morphemes,
+		Pronunciation: vocab.Pronunciation,
+		Meaning:       vocab.Meaning,
+		ReadType:      vocab.ReadType,
+		Difficulty:    vocab.Difficulty,
+		ExampleCount:  vocab.ExampleCount,
+		Roots:         convertVocabularyRoots(vocab.Roots),
+	}
+}
+
+// convertVocabularyRoots 转换组成字根格式
+func convertVocabularyRoots(roots []hanbao.VocabularyRoot) []types.VocabularyRoot {
+	if len(roots) == 0 {
+		return nil
+	}
+	result := make([]types.VocabularyRoot, len(roots))
+	for i, root := range roots {
+		result[i] = types.VocabularyRoot{
+			RootID:   root.RootID,
+			Position: root.Position,
 		}
 	}
 	return result
@@ -147,6 +186,8 @@ func convertStats(stats hanbao.SessionStats) types.SessionStats {
 		UnlockedRoots:  stats.UnlockedRoots,
 		TotalWords:     stats.TotalWords,
 		LearnedWords:   stats.LearnedWords,
+		LanguageWords:  stats.LanguageWords,
+		TextCoverage:   convertTextCoverage(stats.TextCoverage),
 		Accuracy:       stats.Accuracy,
 		AverageTime:    stats.AverageTime,
 		CompletionRate: stats.CompletionRate,
@@ -164,6 +205,10 @@ func convertCharacterRootsForRecommendations(roots []hanbao.CharacterRoot) []typ
 			Difficulty:  root.Difficulty,
 			Tier:        root.Tier,
 			Description: root.Description,
+			MiddleChinese: root.MiddleChinese,
+			Traditional: root.Form(hanbao.VariantTraditional),
+			Japanese:    root.Form(hanbao.VariantJapanese),
+			Korean:      root.Form(hanbao.VariantKorean),
 		}
 	}
 	return result
@@ -1,7 +1,13 @@
 package logic
 
 import (
+	"context"
+	"crypto/subtle"
+	"io"
+	"strings"
+
 	"github.com/zeromicro/go-zero/core/logx"
+	"hanbao-engine/app/hanbao/api/internal/config"
 	"hanbao-engine/app/hanbao/api/internal/svc"
 	"hanbao-engine/app/hanbao/api/internal/types"
 	"hanbao-engine/pkg/hanbao"
@@ -10,14 +16,16 @@ import (
 // HanbaoUnlockLogic 词根解锁仪式逻辑
 type HanbaoUnlockLogic struct {
 	logx.Logger
-	ctx    *svc.ServiceContext
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
 }
 
 // NewHanbaoUnlockLogic 创建解锁逻辑
-func NewHanbaoUnlockLogic(ctx *svc.ServiceContext) *HanbaoUnlockLogic {
+func NewHanbaoUnlockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoUnlockLogic {
 	return &HanbaoUnlockLogic{
-		Logger: logx.WithContext(nil),
+		Logger: logx.WithContext(ctx),
 		ctx:    ctx,
+		svcCtx: svcCtx,
 	}
 }
 
@@ -26,26 +34,198 @@ func (l *HanbaoUnlockLogic) HanbaoUnlock(req *types.UnlockRequest) (resp *types.
 	l.Info("词根解锁请求: ", req.Words)
 
 	// 调用解锁服务
-	result, err := l.ctx.UnlockService.AnalyzeWords(hanbao.UnlockRequest{Words: req.Words})
+	result, err := l.svcCtx.UnlockService.AnalyzeWords(hanbao.UnlockRequest{Words: req.Words, Client: unlockClient(l.svcCtx.Config.Unlock, req.ApiKey)})
 	if err != nil {
 		l.Error("解锁分析失败: ", err)
 		return nil, err
 	}
 
+	// 记录到会话
+	if req.SessionID != "" {
+		if err := recordUnlockedRoots(l.Logger, l.svcCtx, req.SessionID, result.DetectedRoots); err != nil {
+			return nil, err
+		}
+	}
+
 	// 转换为API响应格式
 	resp = &types.UnlockResult{
 		InputWords:     result.InputWords,
+		Segments:       make([]types.Segment, len(result.Segments)),
 		DetectedRoots:  convertCharacterRoots(result.DetectedRoots),
+		RootSources:    make([]types.RootSource, len(result.RootSources)),
 		RootCount:      result.RootCount,
 		UnlockableWords: result.UnlockableWords,
 		WordBreakdown:  result.WordBreakdown,
+		TextCoverage:   convertTextCoverage(result.TextCoverage),
 		Insights:       result.Insights,
 	}
 
+	for i, segment := range result.Segments {
+		resp.Segments[i] = types.Segment{Text: segment.Text, Known: segment.Known, RootIDs: segment.RootIDs}
+	}
+	for i, source := range result.RootSources {
+		resp.RootSources[i] = types.RootSource{RootID: source.RootID, Root: source.Root, Words: source.Words}
+	}
+
 	l.Info("词根解锁成功，发现 ", result.RootCount, " 个字根")
 	return resp, nil
 }
 
+// HanbaoBulkUnlockLogic 批量解锁分析逻辑
+type HanbaoBulkUnlockLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoBulkUnlockLogic 创建批量解锁分析逻辑
+func NewHanbaoBulkUnlockLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoBulkUnlockLogic {
+	return &HanbaoBulkUnlockLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoBulkUnlock 批量分析文本或上传的文件；input 为 nil 时分析请求中的 text 和 words，
+// emit 不为 nil 时流式发送分析过程中的事件
+func (l *HanbaoBulkUnlockLogic) HanbaoBulkUnlock(req *types.BulkUnlockRequest, input io.Reader, emit func(types.BulkUnlockEvent) error) (resp *types.BulkUnlockResult, err error) {
+	if input == nil {
+		lines := req.Words
+		if req.Text != "" {
+			lines = append([]string{req.Text}, lines...)
+		}
+		input = strings.NewReader(strings.Join(lines, "\n"))
+	}
+
+	result, err := l.svcCtx.UnlockService.AnalyzeBulk(input, func(event hanbao.BulkEvent) error {
+		if err := l.ctx.Err(); err != nil {
+			return err
+		}
+		if event.Type == hanbao.BulkEventResult {
+			// 先记录到会话，再发送结果
+			resp = convertBulkUnlockResult(event.Result)
+			if req.SessionID != "" {
+				roots := make([]hanbao.CharacterRoot, len(event.Result.Roots))
+				for i, freq := range event.Result.Roots {
+					roots[i] = freq.Root
+				}
+				if err := recordUnlockedRoots(l.Logger, l.svcCtx, req.SessionID, roots); err != nil {
+					return err
+				}
+			}
+		}
+		if emit == nil {
+			return nil
+		}
+		return emit(convertBulkUnlockEvent(event, resp))
+	})
+	if err != nil {
+		l.Error("批量解锁分析失败: ", err)
+		return nil, err
+	}
+
+	l.Info("批量解锁分析完成，", result.TotalWords, " 个词中发现 ", result.RootCount, " 个字根")
+	return resp, nil
+}
+
+// unlockClient 根据API密钥确定客户端类型，没有密钥或密钥无效时返回空字符串（使用默认上限）；
+// 逐个用常数时间比较，避免通过响应时间猜出密钥
+func unlockClient(c config.UnlockConf, apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	client := ""
+	for key, clientType := range c.ApiKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			client = clientType
+		}
+	}
+	return client
+}
+
+// recordUnlockedRoots 把发现的字根记录到会话，新解锁的词汇加入复习计划
+func recordUnlockedRoots(logger logx.Logger, svcCtx *svc.ServiceContext, sessionID string, roots []hanbao.CharacterRoot) error {
+	session, err := svcCtx.SessionService.RecordUnlockedRoots(sessionID, roots)
+	if err != nil {
+		logger.Error("记录解锁字根失败: ", err)
+		return err
+	}
+
+	if _, err := svcCtx.ReviewService.EnrollUnlocked(session.LearnerID(), session.UnlockedRoots); err != nil {
+		logger.Error("加入复习计划失败: ", err)
+		return err
+	}
+	return nil
+}
+
+// convertBulkUnlockResult 转换批量分析结果
+func convertBulkUnlockResult(result *hanbao.BulkUnlockResult) *types.BulkUnlockResult {
+	resp := &types.BulkUnlockResult{
+		TotalWords:     result.TotalWords,
+		UniqueWords:    result.UniqueWords,
+		MatchedWords:   result.MatchedWords,
+		RootCount:      result.RootCount,
+		Roots:          make([]types.RootFrequency, len(result.Roots)),
+		Coverage:       make([]types.LanguageCoverage, len(result.Coverage)),
+		TextCoverage:   convertTextCoverage(result.TextCoverage),
+		UnmatchedWords: result.UnmatchedWords,
+	}
+	for i, freq := range result.Roots {
+		resp.Roots[i] = types.RootFrequency{
+			Root:      convertCharacterRoots([]hanbao.CharacterRoot{freq.Root})[0],
+			Count:     freq.Count,
+			WordCount: freq.WordCount,
+			Words:     freq.Words,
+		}
+	}
+	for i, c := range result.Coverage {
+		resp.Coverage[i] = types.LanguageCoverage{
+			Language:        c.Language,
+			Name:            c.Name,
+			UnlockableWords: c.UnlockableWords,
+			TotalWords:      c.TotalWords,
+			Percent:         c.Percent,
+		}
+	}
+	return resp
+}
+
+// convertTextCoverage 转换文本覆盖率，没有加载词频表时为 nil
+func convertTextCoverage(coverage []hanbao.TextCoverage) []types.TextCoverage {
+	if coverage == nil {
+		return nil
+	}
+	result := make([]types.TextCoverage, len(coverage))
+	for i, c := range coverage {
+		result[i] = types.TextCoverage{
+			Language:  c.Language,
+			Name:      c.Name,
+			Words:     c.Words,
+			ListWords: c.ListWords,
+			Percent:   c.Percent,
+		}
+	}
+	return result
+}
+
+// convertBulkUnlockEvent 转换批量分析事件，result 事件使用已转换的结果
+func convertBulkUnlockEvent(event hanbao.BulkEvent, result *types.BulkUnlockResult) types.BulkUnlockEvent {
+	resp := types.BulkUnlockEvent{
+		Type:  event.Type,
+		Line:  event.Line,
+		Words: event.Words,
+		Word:  event.Word,
+	}
+	if event.Root != nil {
+		resp.Root = &convertCharacterRoots([]hanbao.CharacterRoot{*event.Root})[0]
+	}
+	if event.Type == hanbao.BulkEventResult {
+		resp.Result = result
+	}
+	return resp
+}
+
 // convertCharacterRoots 转换字根格式
 func convertCharacterRoots(roots []hanbao.CharacterRoot) []types.CharacterRoot {
 	result := make([]types.CharacterRoot, len(roots))
@@ -57,6 +237,10 @@ func convertCharacterRoots(roots []hanbao.CharacterRoot) []types.CharacterRoot {
 			Difficulty:  root.Difficulty,
 			Tier:        root.Tier,
 			Description: root.Description,
+			MiddleChinese: root.MiddleChinese,
+			Traditional: root.Form(hanbao.VariantTraditional),
+			Japanese:    root.Form(hanbao.VariantJapanese),
+			Korean:      root.Form(hanbao.VariantKorean),
 		}
 	}
 	return result
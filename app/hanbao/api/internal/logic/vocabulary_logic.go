@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"hanbao-engine/app/hanbao/api/internal/svc"
+	"hanbao-engine/app/hanbao/api/internal/types"
+	"hanbao-engine/pkg/hanbao"
+)
+
+// HanbaoSearchVocabulariesLogic 词汇搜索逻辑
+type HanbaoSearchVocabulariesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHanbaoSearchVocabulariesLogic 创建词汇搜索逻辑
+func NewHanbaoSearchVocabulariesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HanbaoSearchVocabulariesLogic {
+	return &HanbaoSearchVocabulariesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// HanbaoSearchVocabularies 搜索词汇，不区分大小写和声调
+func (l *HanbaoSearchVocabulariesLogic) HanbaoSearchVocabularies(req *types.SearchVocabulariesRequest) (resp *types.SearchVocabulariesResponse, err error) {
+	vocabs, err := hanbao.SearchVocabularies(l.svcCtx.ContentRepo, req.Query, req.Language, req.Limit)
+	if err != nil {
+		l.Error("搜索词汇失败: ", err)
+		return nil, err
+	}
+
+	resp = &types.SearchVocabulariesResponse{
+		Vocabularies: make([]types.Vocabulary, len(vocabs)),
+	}
+	for i, vocab := range vocabs {
+		resp.Vocabularies[i] = convertVocabulary(vocab)
+	}
+
+	return resp, nil
+}
@@ -1,24 +1,198 @@
 package svc
 
 import (
-	"github.com/zeromicro/go-zero/rest"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"hanbao-engine/app/hanbao/api/internal/config"
 	"hanbao-engine/pkg/hanbao"
 )
 
 // ServiceContext 服务上下文
 type ServiceContext struct {
-	Config         rest.RestConf
+	Config         config.Config
+	ContentRepo    hanbao.ContentRepository
 	UnlockService  *hanbao.UnlockCeremonyService
 	LevelService   *hanbao.LevelService
 	TreasureMapService *hanbao.TreasureMapService
+	SessionService *hanbao.SessionService
+	ReviewService  *hanbao.ReviewService
+	AdaptiveService *hanbao.AdaptiveService
 }
 
 // NewServiceContext 创建服务上下文
-func NewServiceContext(c rest.RestConf) *ServiceContext {
+func NewServiceContext(c config.Config) *ServiceContext {
+	// 框架按 MaxBytes 检查所有请求的 Content-Length，批量分析的上限不能超过它
+	if c.MaxBytes > 0 && c.Unlock.BulkMaxBytes > c.MaxBytes {
+		logx.Must(errors.New("Unlock.BulkMaxBytes 不能大于 MaxBytes"))
+	}
+
+	contentRepo := mustNewContentRepository(c.Content)
+	levelService := mustNewLevelService(c, contentRepo)
+	coverage := mustNewCoverageEstimator(c.Coverage, contentRepo)
+
 	return &ServiceContext{
 		Config:            c,
-		UnlockService:     hanbao.NewUnlockCeremonyService(),
-		LevelService:      hanbao.NewLevelService(),
-		TreasureMapService: hanbao.NewTreasureMapService(),
+		ContentRepo:       contentRepo,
+		UnlockService:     hanbao.NewUnlockCeremonyService(contentRepo, hanbao.UnlockLimits{
+			MaxWords:     c.Unlock.MaxWords,
+			Clients:      c.Unlock.Clients,
+			BulkMaxWords: c.Unlock.BulkMaxWords,
+		}, coverage),
+		LevelService:      levelService,
+		TreasureMapService: hanbao.NewTreasureMapService(contentRepo, coverage),
+		SessionService:    hanbao.NewSessionService(mustNewSessionStore(c)),
+		ReviewService: hanbao.NewReviewService(contentRepo, mustNewReviewStore(c),
+			hanbao.NewFSRS(c.Review.DesiredRetention, c.Review.MaximumInterval)),
+		AdaptiveService: hanbao.NewAdaptiveService(levelService, mustNewRatingStore(c),
+			hanbao.NewEloModel(c.Adaptive.KFactor, c.Adaptive.TargetSuccess, c.Adaptive.DifficultyStep)),
+	}
+}
+
+// mustNewContentRepository 根据配置创建内容仓库
+func mustNewContentRepository(c config.ContentConf) hanbao.ContentRepository {
+	pack := mustLoadContentPack(c.Pack)
+
+	if c.Store != "sql" {
+		return hanbao.NewMemoryContentRepository(pack.Roots, pack.Vocabularies, pack.DialectExamples)
+	}
+
+	conn, err := hanbao.NewSQLConn(c.Driver, c.DataSource)
+	logx.Must(err)
+
+	if c.Migrate {
+		logx.Must(hanbao.MigrateSQL(conn, c.Driver))
+	}
+
+	repo := hanbao.NewSQLContentRepository(conn, c.Driver)
+	if c.Seed {
+		logx.Must(repo.Seed(pack.Roots, pack.Vocabularies, pack.DialectExamples))
+	}
+
+	return repo
+}
+
+// mustLoadContentPack 加载内容包，未配置时使用内置数据
+func mustLoadContentPack(path string) *hanbao.ContentPack {
+	if path == "" {
+		return &hanbao.ContentPack{
+			Roots:           hanbao.CharacterRootsData,
+			Vocabularies:    hanbao.VocabularyData,
+			DialectExamples: hanbao.DialectExamplesData,
+		}
+	}
+
+	pack, err := hanbao.LoadContentPack(path)
+	logx.Must(err)
+	logx.Infof("已加载内容包 %s（版本 %s）：%d个字根，%d个词汇", path, pack.Version,
+		len(pack.Roots), len(pack.Vocabularies))
+
+	return pack
+}
+
+// mustNewCoverageEstimator 加载词频表并创建文本覆盖率估算器，未配置词频表时返回 nil
+func mustNewCoverageEstimator(c config.CoverageConf, repo hanbao.ContentRepository) *hanbao.CoverageEstimator {
+	if len(c.FrequencyLists) == 0 {
+		return nil
+	}
+
+	lists := make([]*hanbao.FrequencyList, 0, len(c.FrequencyLists))
+	for language, path := range c.FrequencyLists {
+		list, err := hanbao.LoadFrequencyList(language, path)
+		logx.Must(err)
+		logx.Infof("已加载%s词频表 %s：%d个词", hanbao.LanguageName(language), path, list.Len())
+		lists = append(lists, list)
+	}
+
+	estimator, err := hanbao.NewCoverageEstimator(repo, lists...)
+	logx.Must(err)
+	return estimator
+}
+
+// mustNewSessionStore 根据配置创建会话存储
+func mustNewSessionStore(c config.Config) hanbao.SessionStore {
+	expire := time.Duration(c.Session.Expire) * time.Second
+
+	switch c.Session.Store {
+	case "redis":
+		if len(c.Cache) == 0 {
+			logx.Must(errors.New("会话存储使用 redis 时必须配置 Cache"))
+		}
+		return hanbao.NewRedisSessionStore(c.Cache, expire)
+	case "sql":
+		conn, driver := mustOpenSQL(c, c.Session.Driver, c.Session.DataSource)
+		return hanbao.NewSQLSessionStore(conn, driver, expire)
+	default:
+		store, err := hanbao.NewMemorySessionStore(expire)
+		logx.Must(err)
+		return store
+	}
+}
+
+// mustNewReviewStore 根据配置创建复习卡片存储
+func mustNewReviewStore(c config.Config) hanbao.ReviewStore {
+	if c.Review.Store == "sql" {
+		conn, driver := mustOpenSQL(c, c.Review.Driver, c.Review.DataSource)
+		return hanbao.NewSQLReviewStore(conn, driver)
+	}
+	return hanbao.NewMemoryReviewStore()
+}
+
+// mustNewRatingStore 根据配置创建能力评分存储
+func mustNewRatingStore(c config.Config) hanbao.RatingStore {
+	if c.Adaptive.Store == "sql" {
+		conn, driver := mustOpenSQL(c, c.Adaptive.Driver, c.Adaptive.DataSource)
+		return hanbao.NewSQLRatingStore(conn, driver)
+	}
+	return hanbao.NewMemoryRatingStore()
+}
+
+// mustOpenSQL 打开数据库连接，驱动和数据源为空时使用 Content 的配置
+func mustOpenSQL(c config.Config, driver, dataSource string) (sqlx.SqlConn, string) {
+	if driver == "" {
+		driver = c.Content.Driver
+	}
+	if dataSource == "" {
+		dataSource = c.Content.DataSource
+	}
+
+	conn, err := hanbao.NewSQLConn(driver, dataSource)
+	logx.Must(err)
+	if c.Content.Migrate {
+		logx.Must(hanbao.MigrateSQL(conn, driver))
+	}
+	return conn, driver
+}
+
+// mustNewLevelService 根据配置创建关卡服务
+func mustNewLevelService(c config.Config, repo hanbao.ContentRepository) *hanbao.LevelService {
+	expire := time.Duration(c.Level.Expire) * time.Second
+
+	// 多个实例共享答题记录时必须使用相同的密钥签发关卡ID，内存存储未配置密钥时使用随机密钥
+	if c.Level.Store != "memory" && c.Level.Secret == "" {
+		logx.Must(fmt.Errorf("关卡存储使用 %s 时必须配置 Level.Secret", c.Level.Store))
+	}
+	signer, err := hanbao.NewLevelSigner(c.Level.Secret)
+	logx.Must(err)
+
+	switch c.Level.Store {
+	case "token":
+		tokens, err := hanbao.NewAnswerTokenCodec(c.Level.Secret)
+		logx.Must(err)
+		return hanbao.NewStatelessLevelService(repo, tokens, signer, expire)
+	case "redis":
+		if len(c.Cache) == 0 {
+			logx.Must(errors.New("关卡存储使用 redis 时必须配置 Cache"))
+		}
+		store, err := hanbao.NewRedisLevelStore(c.Cache, expire)
+		logx.Must(err)
+		return hanbao.NewLevelService(repo, store, signer)
+	default:
+		store, err := hanbao.NewMemoryLevelStore(expire)
+		logx.Must(err)
+		return hanbao.NewLevelService(repo, store, signer)
 	}
 }
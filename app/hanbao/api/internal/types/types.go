@@ -5,18 +5,84 @@ import "time"
 
 type (
 	UnlockRequest struct {
-		Words []string `json:"words"`
+		Words     []string `json:"words"`
+		SessionID string   `json:"session_id,optional"`
+		ApiKey    string   `header:"X-Api-Key,optional"`
 	}
 
 	UnlockResult struct {
 		InputWords      []string        `json:"input_words"`
+		Segments        []Segment       `json:"segments"`
 		DetectedRoots   []CharacterRoot `json:"detected_roots"`
+		RootSources     []RootSource    `json:"root_sources"`
 		RootCount       int             `json:"root_count"`
 		UnlockableWords int             `json:"unlockable_words"`
 		WordBreakdown   map[string]int  `json:"word_breakdown"`
+		TextCoverage    []TextCoverage  `json:"text_coverage,omitempty"`
 		Insights        []string        `json:"insights"`
 	}
 
+	Segment struct {
+		Text    string  `json:"text"`
+		Known   bool    `json:"known"`
+		RootIDs []int64 `json:"root_ids,omitempty"`
+	}
+
+	RootSource struct {
+		RootID int64    `json:"root_id"`
+		Root   string   `json:"root"`
+		Words  []string `json:"words"`
+	}
+
+	BulkUnlockRequest struct {
+		Text      string   `json:"text,optional"`
+		Words     []string `json:"words,optional"`
+		SessionID string   `json:"session_id,optional"`
+	}
+
+	BulkUnlockResult struct {
+		TotalWords     int                `json:"total_words"`
+		UniqueWords    int                `json:"unique_words"`
+		MatchedWords   int                `json:"matched_words"`
+		RootCount      int                `json:"root_count"`
+		Roots          []RootFrequency    `json:"roots"`
+		Coverage       []LanguageCoverage `json:"coverage"`
+		TextCoverage   []TextCoverage     `json:"text_coverage,omitempty"`
+		UnmatchedWords []string           `json:"unmatched_words"`
+	}
+
+	BulkUnlockEvent struct {
+		Type   string            `json:"type"`
+		Line   int               `json:"line"`
+		Words  int               `json:"words"`
+		Word   string            `json:"word,omitempty"`
+		Root   *CharacterRoot    `json:"root,omitempty"`
+		Result *BulkUnlockResult `json:"result,omitempty"`
+	}
+
+	RootFrequency struct {
+		Root      CharacterRoot `json:"root"`
+		Count     int           `json:"count"`
+		WordCount int           `json:"word_count"`
+		Words     []string      `json:"words"`
+	}
+
+	LanguageCoverage struct {
+		Language        string  `json:"language"`
+		Name            string  `json:"name"`
+		UnlockableWords int     `json:"unlockable_words"`
+		TotalWords      int     `json:"total_words"`
+		Percent         float64 `json:"percent"`
+	}
+
+	TextCoverage struct {
+		Language  string  `json:"language"`
+		Name      string  `json:"name"`
+		Words     int     `json:"words"`
+		ListWords int     `json:"list_words"`
+		Percent   float64 `json:"percent"`
+	}
+
 	CharacterRoot struct {
 		ID          int64  `json:"id"`
 		Root        string `json:"root"`
@@ -24,10 +90,14 @@ type (
 		Difficulty  int    `json:"difficulty"`
 		Tier        int    `json:"tier"`
 		Description string `json:"description"`
+		MiddleChinese string `json:"middle_chinese,omitempty"`
+		Traditional string `json:"traditional"`
+		Japanese    string `json:"japanese"`
+		Korean      string `json:"korean"`
 	}
 
 	StartSessionRequest struct {
-		UserID string `json:"user_id,omitempty"`
+		UserID string `json:"user_id,optional"`
 	}
 
 	StartSessionResponse struct {
@@ -47,6 +117,7 @@ type (
 		TimeLimit   int      `json:"time_limit"`
 		Questions   []Question `json:"questions"`
 		Reward      Reward   `json:"reward"`
+		AnswerToken string   `json:"answer_token,omitempty"`
 	}
 
 	Question struct {
@@ -54,9 +125,19 @@ type (
 		Type         string   `json:"type"`
 		Content      string   `json:"content"`
 		Options      []string `json:"options,omitempty"`
-		CorrectAnswer string  `json:"correct_answer"`
+		Matching     *MatchingGrid `json:"matching,omitempty"`
 		Hint         string   `json:"hint,omitempty"`
-		Explanation  string   `json:"explanation"`
+		Score        int      `json:"score"`
+	}
+
+	MatchingGrid struct {
+		Rows    []string         `json:"rows"`
+		Columns []MatchingColumn `json:"columns"`
+	}
+
+	MatchingColumn struct {
+		Label   string   `json:"label"`
+		Options []string `json:"options"`
 	}
 
 	Reward struct {
@@ -65,7 +146,26 @@ type (
 		Achievement string `json:"achievement,omitempty"`
 	}
 
+	LevelType struct {
+		Type           string   `json:"type"`
+		Aliases        []string `json:"aliases"`
+		Title          string   `json:"title"`
+		Icon           string   `json:"icon"`
+		Description    string   `json:"description"`
+		TimeLimit      int      `json:"time_limit"`
+		Score          int      `json:"score"`
+		RequiredData   []string `json:"required_data"`
+		AvailableRoots []int64  `json:"available_roots"`
+	}
+
+	LevelTypesResponse struct {
+		LevelTypes []LevelType `json:"level_types"`
+	}
+
 	AnswerRequest struct {
+		LevelId    string `path:"levelId"`
+		SessionID  string `json:"session_id,optional"`
+		AnswerToken string `json:"answer_token,optional"`
 		QuestionID string `json:"question_id"`
 		Answer     string `json:"answer"`
 	}
@@ -73,8 +173,21 @@ type (
 	AnswerResult struct {
 		Correct     bool   `json:"correct"`
 		Score       int    `json:"score"`
+		CorrectAnswer string `json:"correct_answer"`
 		Explanation string `json:"explanation"`
 		NextHint    string `json:"next_hint,omitempty"`
+		LevelCompleted bool `json:"level_completed"`
+		SoundRules  []SoundRule `json:"sound_rules,omitempty"`
+	}
+
+	SoundRule struct {
+		ID       string   `json:"id"`
+		Part     string   `json:"part"`
+		From     []string `json:"from"`
+		Korean   []string `json:"korean"`
+		Japanese []string `json:"japanese"`
+		Note     string   `json:"note"`
+		Example  string   `json:"example"`
 	}
 
 	TreasureMap struct {
@@ -93,11 +206,20 @@ type (
 		Language       string `json:"language"`
 		Word           string `json:"word"`
 		Romaji         string `json:"romaji,omitempty"`
+		Hanja          string `json:"hanja,omitempty"`
+		Chinese        string `json:"chinese,omitempty"`
+		Morphemes      string `json:"morphemes,omitempty"`
 		Pronunciation  string `json:"pronunciation"`
 		Meaning        string `json:"meaning"`
 		ReadType       string `json:"read_type,omitempty"`
 		Difficulty     int    `json:"difficulty"`
 		ExampleCount   int    `json:"example_count"`
+		Roots          []VocabularyRoot `json:"roots,omitempty"`
+	}
+
+	VocabularyRoot struct {
+		RootID   int64 `json:"root_id"`
+		Position int   `json:"position"`
 	}
 
 	Connection struct {
@@ -122,6 +244,8 @@ type (
 		UnlockedRoots  int     `json:"unlocked_roots"`
 		TotalWords     int     `json:"total_words"`
 		LearnedWords   int     `json:"learned_words"`
+		LanguageWords  map[string]int `json:"language_words"`
+		TextCoverage   []TextCoverage `json:"text_coverage,omitempty"`
 		Accuracy       float64 `json:"accuracy"`
 		AverageTime    int     `json:"average_time"`
 		CompletionRate float64 `json:"completion_rate"`
@@ -133,6 +257,102 @@ type (
 		NextGoals       []string       `json:"next_goals"`
 	}
 
+	DueReviewsRequest struct {
+		SessionID string `path:"sessionId"`
+		Limit     int    `form:"limit,default=20,range=[1:100]"`
+	}
+
+	DueReviewsResponse struct {
+		Reviews []DueReview `json:"reviews"`
+	}
+
+	DueReview struct {
+		Card       ReviewCard `json:"card"`
+		Vocabulary Vocabulary `json:"vocabulary"`
+	}
+
+	ReviewCard struct {
+		VocabularyID int64   `json:"vocabulary_id"`
+		Stability    float64 `json:"stability"`
+		Difficulty   float64 `json:"difficulty"`
+		Reps         int     `json:"reps"`
+		Lapses       int     `json:"lapses"`
+		Due          string  `json:"due"`
+		LastReview   string  `json:"last_review,omitempty"`
+	}
+
+	SubmitReviewRequest struct {
+		SessionID    string `path:"sessionId"`
+		VocabularyID int64  `json:"vocabulary_id"`
+		Grade        int    `json:"grade"`
+	}
+
+	ReviewForecastRequest struct {
+		SessionID string `path:"sessionId"`
+		Days      int    `form:"days,default=7,range=[1:90]"`
+	}
+
+	ReviewForecastResponse struct {
+		Days []ReviewForecastDay `json:"days"`
+	}
+
+	ReviewForecastDay struct {
+		Date  string `json:"date"`
+		Count int    `json:"count"`
+	}
+
+	RootReadingsRequest struct {
+		RootID int64 `path:"rootId"`
+	}
+
+	RootReadingsResponse struct {
+		Root        CharacterRoot       `json:"root"`
+		Predictions []ReadingPrediction `json:"predictions"`
+		Readings    []RootReading       `json:"readings"`
+	}
+
+	ReadingPrediction struct {
+		Language string      `json:"language"`
+		Source   string      `json:"source"`
+		Pattern  string      `json:"pattern"`
+		Rules    []SoundRule `json:"rules"`
+	}
+
+	RootReading struct {
+		Language     string  `json:"language"`
+		Reading      string  `json:"reading"`
+		VocabularyID int64   `json:"vocabulary_id"`
+		Word         string  `json:"word"`
+		Score        float64 `json:"score"`
+		Explanation  string  `json:"explanation"`
+	}
+
+	SearchVocabulariesRequest struct {
+		Query    string `form:"q"`
+		Language string `form:"language,optional,options=ja|ko|en|vi"`
+		Limit    int    `form:"limit,default=20,range=[1:100]"`
+	}
+
+	SearchVocabulariesResponse struct {
+		Vocabularies []Vocabulary `json:"vocabularies"`
+	}
+
+	RomanizeRequest struct {
+		Text     string `json:"text"`
+		Language string `json:"language,optional,options=ja|ko"`
+		Scheme   string `json:"scheme,optional,options=hepburn|kunrei|revised"`
+		Macrons  bool   `json:"macrons,optional"`
+	}
+
+	RomanizeResponse struct {
+		Language  string   `json:"language"`
+		Scheme    string   `json:"scheme"`
+		Text      string   `json:"text"`
+		Romanized string   `json:"romanized"`
+		Syllables []string `json:"syllables,omitempty"`
+		Unknown   []string `json:"unknown,omitempty"`
+	}
+
 	// Additional types for handlers
 	TreasureMapRequest struct {
 		SessionID string `path:"sessionId"`
@@ -145,4 +365,8 @@ type (
 	LevelRequest struct {
 		LevelId string `path:"levelId"`
 	}
+
+	NextLevelRequest struct {
+		SessionID string `path:"sessionId"`
+	}
 )
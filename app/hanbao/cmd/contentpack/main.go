@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"hanbao-engine/pkg/hanbao"
+)
+
+const usage = `内容包工具
+
+用法:
+  contentpack validate <pack>            校验内容包
+  contentpack convert <pack> <output>    转换内容包格式（按输出路径扩展名判断，无扩展名导出为CSV目录）
+  contentpack export <output>            导出内置数据为内容包
+
+内容包可以是 .json / .yaml 文件，或包含 pack.yaml、roots.csv、vocabularies.csv、dialect_examples.csv 的目录
+`
+
+var version = flag.String("version", "builtin", "导出内置数据时使用的内容包版本")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch {
+	case args[0] == "validate" && len(args) == 2:
+		err = validate(args[1])
+	case args[0] == "convert" && len(args) == 3:
+		err = convert(args[1], args[2])
+	case args[0] == "export" && len(args) == 2:
+		err = export(args[1])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+// validate 校验内容包
+func validate(path string) error {
+	pack, err := hanbao.LoadContentPack(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 内容包校验通过（版本 %s）：%d个字根，%d个词汇，%d个方言示例\n",
+		pack.Version, len(pack.Roots), len(pack.Vocabularies), len(pack.DialectExamples))
+	return nil
+}
+
+// convert 转换内容包格式
+func convert(input, output string) error {
+	pack, err := hanbao.LoadContentPack(input)
+	if err != nil {
+		return err
+	}
+
+	return writePack(pack, output)
+}
+
+// export 导出内置数据
+func export(output string) error {
+	pack, err := hanbao.NewContentPackFromRepository(hanbao.NewDefaultContentRepository(), *version)
+	if err != nil {
+		return err
+	}
+
+	if err := pack.Validate(); err != nil {
+		return err
+	}
+
+	return writePack(pack, output)
+}
+
+// writePack 按输出路径的格式写入内容包
+func writePack(pack *hanbao.ContentPack, output string) error {
+	format, err := hanbao.DetectPackFormat(output)
+	if err != nil {
+		return err
+	}
+
+	if err := pack.Export(output, format); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 已导出 %s 格式内容包: %s\n", format, output)
+	return nil
+}
@@ -0,0 +1,205 @@
+package hanbao
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// planTolerance 答对概率与目标相差不超过最优值加上该容差的关卡都可能被选中，避免总是重复同一关
+const planTolerance = 0.05
+
+// LevelPlan 自适应选择的下一关
+type LevelPlan struct {
+	LevelType          string  `json:"level_type"`
+	RootID             int64   `json:"root_id"`
+	Difficulty         int     `json:"difficulty"`
+	SuccessProbability float64 `json:"success_probability"` // 预计的答对概率
+}
+
+// AdaptiveService 自适应难度服务，根据答题结果更新学习者和题目的 Elo 评分，
+// 并据此选择下一关的关卡类型、字根和难度
+type AdaptiveService struct {
+	levels *LevelService
+	store  RatingStore
+	model  *EloModel
+	rng    *rand.Rand
+	mu     sync.Mutex // 保护评分的读-改-写和 rng
+}
+
+// NewAdaptiveService 创建自适应难度服务
+func NewAdaptiveService(levels *LevelService, store RatingStore, model *EloModel) *AdaptiveService {
+	return &AdaptiveService{
+		levels: levels,
+		store:  store,
+		model:  model,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// PlanNextLevel 在已解锁字根可以生成的关卡中，选择答对概率最接近目标的关卡类型、字根和难度
+func (s *AdaptiveService) PlanNextLevel(userID string, unlockedRoots []int64) (*LevelPlan, error) {
+	plans, err := s.plan(userID, unlockedRoots, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &plans[0], nil
+}
+
+// NextLevel 按 PlanNextLevel 的选择生成下一关
+func (s *AdaptiveService) NextLevel(userID string, unlockedRoots []int64) (*Level, error) {
+	plan, err := s.PlanNextLevel(userID, unlockedRoots)
+	if err != nil {
+		return nil, err
+	}
+	return s.levels.GenerateLevel(plan.LevelType, plan.RootID, plan.Difficulty)
+}
+
+// GenerateSessionLevels 为用户会话生成关卡序列，同一字根的同一关卡类型尽量不重复
+func (s *AdaptiveService) GenerateSessionLevels(userID string, unlockedRoots []int64) ([]Level, error) {
+	plans, err := s.plan(userID, unlockedRoots, sessionLevelCount(len(unlockedRoots)))
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]Level, 0, len(plans))
+	for _, plan := range plans {
+		level, err := s.levels.GenerateLevel(plan.LevelType, plan.RootID, plan.Difficulty)
+		if err != nil {
+			continue // 跳过无法生成的关卡
+		}
+		levels = append(levels, *level)
+	}
+
+	return levels, nil
+}
+
+// RecordAnswer 将一次答题结果计入学习者和题目的评分
+func (s *AdaptiveService) RecordAnswer(userID string, result *AnswerResult, now time.Time) error {
+	if result.LevelType == "" {
+		return nil
+	}
+
+	root, err := s.levels.repo.GetRootByID(result.RootID)
+	if errors.Is(err, ErrContentNotFound) {
+		return nil // 字根已从内容中移除
+	}
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	learner, err := s.learnerRating(userID, result.LevelType)
+	if err != nil {
+		return err
+	}
+	item, err := s.itemRating(result.LevelType, root)
+	if err != nil {
+		return err
+	}
+
+	s.model.Update(learner, item, result.Difficulty, result.Correct, now)
+	if err := s.store.Save(learner); err != nil {
+		return err
+	}
+	return s.store.Save(item)
+}
+
+// plan 选择 count 个关卡，候选关卡用完后允许重复
+func (s *AdaptiveService) plan(userID string, unlockedRoots []int64, count int) ([]LevelPlan, error) {
+	if len(unlockedRoots) == 0 {
+		return nil, ErrNoUnlockedRoots
+	}
+
+	options, err := s.levels.AvailableLevels(unlockedRoots)
+	if err != nil {
+		return nil, err
+	}
+	if len(options) == 0 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root_ids": unlockedRoots})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := make([]LevelPlan, len(options))
+	for i, option := range options {
+		learner, err := s.learnerRating(userID, option.Type)
+		if err != nil {
+			return nil, err
+		}
+		item, err := s.itemRating(option.Type, &option.Root)
+		if err != nil {
+			return nil, err
+		}
+
+		difficulty := s.model.BestDifficulty(learner.Rating, item.Rating)
+		candidates[i] = LevelPlan{
+			LevelType:          option.Type,
+			RootID:             option.Root.ID,
+			Difficulty:         difficulty,
+			SuccessProbability: s.model.Expected(learner.Rating, item.Rating, difficulty),
+		}
+	}
+
+	plans := make([]LevelPlan, 0, count)
+	remaining := candidates
+	for len(plans) < count {
+		if len(remaining) == 0 {
+			remaining = candidates
+		}
+		i := s.pick(remaining)
+		plans = append(plans, remaining[i])
+		remaining = append(remaining[:i:i], remaining[i+1:]...)
+	}
+
+	return plans, nil
+}
+
+// pick 在答对概率最接近目标的候选关卡中随机选择一个，调用方需持有锁
+func (s *AdaptiveService) pick(candidates []LevelPlan) int {
+	best := math.Inf(1)
+	for _, c := range candidates {
+		best = math.Min(best, math.Abs(c.SuccessProbability-s.model.TargetSuccess))
+	}
+
+	var near []int
+	for i, c := range candidates {
+		if math.Abs(c.SuccessProbability-s.model.TargetSuccess) <= best+planTolerance {
+			near = append(near, i)
+		}
+	}
+	return near[s.rng.Intn(len(near))]
+}
+
+// learnerRating 获取学习者的评分，不存在时返回初始评分
+func (s *AdaptiveService) learnerRating(userID, levelType string) (*SkillRating, error) {
+	subject := learnerSubject(userID, levelType)
+	rating, ok, err := s.store.Get(subject)
+	if err != nil || ok {
+		return rating, err
+	}
+	return &SkillRating{Subject: subject, Rating: defaultLearnerRating}, nil
+}
+
+// itemRating 获取题目的评分，不存在时按字根难度给出初始评分
+func (s *AdaptiveService) itemRating(levelType string, root *CharacterRoot) (*SkillRating, error) {
+	subject := itemSubject(levelType, root.ID)
+	rating, ok, err := s.store.Get(subject)
+	if err != nil || ok {
+		return rating, err
+	}
+	return &SkillRating{
+		Subject: subject,
+		Rating:  defaultLearnerRating + float64(max(root.Difficulty-1, 0))*rootDifficultyRating,
+	}, nil
+}
+
+// sessionLevelCount 一次会话的目标关卡数，每个字根最多2个关卡，最多5个
+func sessionLevelCount(unlockedRoots int) int {
+	return min(5, unlockedRoots*2)
+}
@@ -0,0 +1,168 @@
+package hanbao
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// AnswerKey 关卡的答案表，加密后作为答案令牌随关卡下发
+type AnswerKey struct {
+	LevelID    string              `json:"l"`
+	LevelType  string              `json:"t"`
+	RootID     int64               `json:"r"`
+	Difficulty int                 `json:"d"`
+	ExpiresAt  int64               `json:"e"` // 过期时间（Unix秒）
+	Questions  []AnswerKeyQuestion `json:"q"`
+}
+
+// AnswerKeyQuestion 单个问题的标准答案
+type AnswerKeyQuestion struct {
+	ID            string   `json:"i"`
+	Type          string   `json:"y,omitempty"`
+	CorrectAnswer string   `json:"a"`
+	Explanation   string   `json:"x,omitempty"`
+	Hint          string   `json:"h,omitempty"`
+	Score         int      `json:"s"`
+	VocabularyIDs []int64  `json:"v,omitempty"`
+	SoundRules    []string `json:"r,omitempty"`
+	RuleLanguage  string   `json:"l,omitempty"`
+}
+
+// NewAnswerKey 根据关卡生成答案表
+func NewAnswerKey(level *Level, expiresAt time.Time) *AnswerKey {
+	key := &AnswerKey{
+		LevelID:    level.ID,
+		LevelType:  level.Type,
+		RootID:     level.RootID,
+		Difficulty: level.Difficulty,
+		ExpiresAt:  expiresAt.Unix(),
+		Questions:  make([]AnswerKeyQuestion, len(level.Questions)),
+	}
+	for i, q := range level.Questions {
+		key.Questions[i] = AnswerKeyQuestion{
+			ID:            q.ID,
+			Type:          q.Type,
+			CorrectAnswer: q.CorrectAnswer,
+			Explanation:   q.Explanation,
+			Hint:          q.Hint,
+			Score:         q.Score,
+			VocabularyIDs: q.VocabularyIDs,
+			SoundRules:    q.SoundRules,
+			RuleLanguage:  q.RuleLanguage,
+		}
+	}
+	return key
+}
+
+// Question 根据问题ID获取标准答案
+func (k *AnswerKey) Question(questionID string) (*Question, bool) {
+	for _, q := range k.Questions {
+		if q.ID == questionID {
+			return &Question{
+				ID:            q.ID,
+				Type:          q.Type,
+				CorrectAnswer: q.CorrectAnswer,
+				Explanation:   q.Explanation,
+				Hint:          q.Hint,
+				Score:         q.Score,
+				VocabularyIDs: q.VocabularyIDs,
+				SoundRules:    q.SoundRules,
+				RuleLanguage:  q.RuleLanguage,
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// AnswerTokenCodec 答案令牌编解码器，使用 AES-CTR 加密后再以 HMAC-SHA256 签名
+type AnswerTokenCodec struct {
+	encKey []byte
+	macKey []byte
+}
+
+// NewAnswerTokenCodec 根据密钥创建答案令牌编解码器，加密密钥和签名密钥由 secret 派生
+func NewAnswerTokenCodec(secret string) (*AnswerTokenCodec, error) {
+	if len(secret) < 16 {
+		return nil, errors.New("答案令牌密钥至少需要16个字符")
+	}
+
+	return &AnswerTokenCodec{
+		encKey: deriveKey(secret, "hanbao-answer-token-enc"),
+		macKey: deriveKey(secret, "hanbao-answer-token-mac"),
+	}, nil
+}
+
+// Seal 加密并签名答案表
+func (c *AnswerTokenCodec) Seal(key *AnswerKey) (string, error) {
+	plain, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(c.encKey)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, aes.BlockSize+len(plain), aes.BlockSize+len(plain)+sha256.Size)
+	iv := buf[:aes.BlockSize]
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+	cipher.NewCTR(block, iv).XORKeyStream(buf[aes.BlockSize:], plain)
+
+	buf = append(buf, c.sign(buf)...)
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Open 校验签名并解密答案表，令牌过期时返回 ErrAnswerTokenExpired
+func (c *AnswerTokenCodec) Open(token string) (*AnswerKey, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) < aes.BlockSize+sha256.Size {
+		return nil, ErrInvalidAnswerToken
+	}
+
+	body, mac := buf[:len(buf)-sha256.Size], buf[len(buf)-sha256.Size:]
+	if !hmac.Equal(mac, c.sign(body)) {
+		return nil, ErrInvalidAnswerToken
+	}
+
+	block, err := aes.NewCipher(c.encKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, len(body)-aes.BlockSize)
+	cipher.NewCTR(block, body[:aes.BlockSize]).XORKeyStream(plain, body[aes.BlockSize:])
+
+	var key AnswerKey
+	if err := json.Unmarshal(plain, &key); err != nil {
+		return nil, ErrInvalidAnswerToken
+	}
+	if time.Now().Unix() > key.ExpiresAt {
+		return nil, ErrAnswerTokenExpired
+	}
+
+	return &key, nil
+}
+
+// sign 计算 HMAC-SHA256 签名
+func (c *AnswerTokenCodec) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, c.macKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// deriveKey 从密钥派生指定用途的32字节子密钥
+func deriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,69 @@
+package hanbao
+
+import "sort"
+
+// CognateSet 同一个汉字词在各语言中的形式，通过 Vocabulary.Chinese 关联，如 电话 / 電話 / 전화
+type CognateSet struct {
+	Chinese string                `json:"chinese"`
+	Words   map[string]Vocabulary `json:"words"` // 语言 → 词汇
+}
+
+// HasRoot 判断同源词组中是否有词汇包含指定字根
+func (c CognateSet) HasRoot(rootID int64) bool {
+	for _, vocab := range c.Words {
+		if vocab.HasRoot(rootID) {
+			return true
+		}
+	}
+	return false
+}
+
+// VocabularyIDs 同源词组中各语言词汇的ID，按 languages 的顺序排列
+func (c CognateSet) VocabularyIDs(languages ...string) []int64 {
+	ids := make([]int64, 0, len(languages))
+	for _, language := range languages {
+		if vocab, ok := c.Words[language]; ok {
+			ids = append(ids, vocab.ID)
+		}
+	}
+	return ids
+}
+
+// CognateSets 按中文词把词汇分组，只返回 languages 中每种语言都有词汇的组；
+// 同一语言有多个词时取ID最小的，结果按组内最小的词汇ID排序
+func CognateSets(vocabs []Vocabulary, languages ...string) []CognateSet {
+	sorted := make([]Vocabulary, len(vocabs))
+	copy(sorted, vocabs)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	wanted := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		wanted[language] = true
+	}
+
+	var order []string
+	sets := make(map[string]*CognateSet)
+	for _, vocab := range sorted {
+		if vocab.Chinese == "" || !wanted[vocab.Language] {
+			continue
+		}
+
+		set, ok := sets[vocab.Chinese]
+		if !ok {
+			set = &CognateSet{Chinese: vocab.Chinese, Words: make(map[string]Vocabulary)}
+			sets[vocab.Chinese] = set
+			order = append(order, vocab.Chinese)
+		}
+		if _, ok := set.Words[vocab.Language]; !ok {
+			set.Words[vocab.Language] = vocab
+		}
+	}
+
+	var result []CognateSet
+	for _, chinese := range order {
+		if set := sets[chinese]; len(set.Words) == len(wanted) {
+			result = append(result, *set)
+		}
+	}
+	return result
+}
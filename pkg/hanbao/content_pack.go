@@ -0,0 +1,580 @@
+package hanbao
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// 内容包格式
+const (
+	PackFormatJSON = "json"
+	PackFormatYAML = "yaml"
+	PackFormatCSV  = "csv"
+)
+
+// CSV 内容包目录中的文件名
+const (
+	packManifestFile       = "pack.yaml"
+	packRootsFile          = "roots.csv"
+	packVocabulariesFile   = "vocabularies.csv"
+	packDialectExampleFile = "dialect_examples.csv"
+)
+
+// optionalCSVColumns 可以省略的CSV列
+var optionalCSVColumns = map[string]bool{
+	"roots":          true, // 组成字根，格式为 字根ID:位置，多个用分号分隔，如 1:0;2:1
+	"hanja":          true, // 韩语汉字词逐音节对应的汉字，如 電話
+	"chinese":        true, // 用简体字写出的同一个汉字词，如 电话
+	"morphemes":      true, // 非汉字词的词素及对应汉字的位置，如 tele:0+phone:1
+	"middle_chinese": true, // 字根的中古汉语拟音（白一平转写），如 denH
+	"traditional":    true, // 字根的繁体字，与简体相同时留空
+	"japanese":       true, // 字根的日本新字体，与简体相同时留空
+	"korean":         true, // 字根的韩国汉字，与简体相同时留空
+}
+
+var (
+	rootCSVHeader           = []string{"id", "root", "pinyin", "difficulty", "tier", "description", "middle_chinese", "traditional", "japanese", "korean"}
+	vocabularyCSVHeader     = []string{"id", "root_id", "language", "word", "romaji", "pronunciation", "meaning", "read_type", "difficulty", "example_count", "roots", "hanja", "chinese", "morphemes"}
+	dialectExampleCSVHeader = []string{"id", "root_id", "standard", "dialect", "dialect_type", "description", "audio_url"}
+)
+
+// ContentPack 内容包，包含字根、词汇和方言示例
+type ContentPack struct {
+	Name            string           `json:"name,omitempty"`
+	Version         string           `json:"version"`   // 内容包版本，如 "2024.1"
+	Languages       []string         `json:"languages"` // 内容包包含的语言，如 ["ja", "ko"]
+	Roots           []CharacterRoot  `json:"roots"`
+	Vocabularies    []Vocabulary     `json:"vocabularies"`
+	DialectExamples []DialectExample `json:"dialect_examples"`
+
+	sources map[string]packSource // 各部分的来源，用于校验时定位文件和行号
+}
+
+// packSource 内容包某一部分的来源
+type packSource struct {
+	file     string
+	firstRow int // 第一条记录所在的行号
+}
+
+// 内容包的各个部分
+const (
+	packSectionManifest        = "manifest"
+	packSectionRoots           = "roots"
+	packSectionVocabularies    = "vocabularies"
+	packSectionDialectExamples = "dialect_examples"
+)
+
+// NewContentPackFromRepository 从内容仓库导出内容包
+func NewContentPackFromRepository(repo ContentRepository, version string) (*ContentPack, error) {
+	roots, err := repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+	vocabularies, err := repo.ListVocabularies()
+	if err != nil {
+		return nil, err
+	}
+	dialectExamples, err := repo.ListDialectExamples()
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, vocab := range vocabularies {
+		if !seen[vocab.Language] {
+			seen[vocab.Language] = true
+			languages = append(languages, vocab.Language)
+		}
+	}
+
+	return &ContentPack{
+		Version:         version,
+		Languages:       languages,
+		Roots:           roots,
+		Vocabularies:    vocabularies,
+		DialectExamples: dialectExamples,
+	}, nil
+}
+
+// DetectPackFormat 根据路径判断内容包格式，目录视为CSV内容包
+func DetectPackFormat(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() {
+		return PackFormatCSV, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return PackFormatJSON, nil
+	case ".yaml", ".yml":
+		return PackFormatYAML, nil
+	case "":
+		return PackFormatCSV, nil
+	default:
+		return "", fmt.Errorf("无法识别的内容包格式: %s", path)
+	}
+}
+
+// LoadContentPack 加载并校验内容包
+func LoadContentPack(path string) (*ContentPack, error) {
+	format, err := DetectPackFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pack *ContentPack
+	switch format {
+	case PackFormatJSON:
+		pack, err = loadJSONPack(path)
+	case PackFormatYAML:
+		pack, err = loadYAMLPack(path)
+	default:
+		pack, err = loadCSVPack(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pack.fillTimestamps()
+	pack.fillRomanization()
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// Export 按指定格式导出内容包，CSV 格式写入目录
+func (p *ContentPack) Export(path, format string) error {
+	switch format {
+	case PackFormatJSON:
+		data, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, 0o644)
+	case PackFormatYAML:
+		data, err := marshalYAML(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, 0o644)
+	case PackFormatCSV:
+		return p.exportCSV(path)
+	default:
+		return fmt.Errorf("不支持的内容包格式: %s", format)
+	}
+}
+
+// fillTimestamps 为缺少时间的记录补充当前时间
+func (p *ContentPack) fillTimestamps() {
+	now := time.Now()
+	for i := range p.Roots {
+		if p.Roots[i].CreatedAt.IsZero() {
+			p.Roots[i].CreatedAt = now
+		}
+		if p.Roots[i].UpdatedAt.IsZero() {
+			p.Roots[i].UpdatedAt = now
+		}
+	}
+	for i := range p.Vocabularies {
+		if p.Vocabularies[i].CreatedAt.IsZero() {
+			p.Vocabularies[i].CreatedAt = now
+		}
+		if p.Vocabularies[i].UpdatedAt.IsZero() {
+			p.Vocabularies[i].UpdatedAt = now
+		}
+	}
+}
+
+// fillRomanization 为未填写的日语罗马字和韩语发音补充自动转写，已填写的由 Validate 交叉校验
+func (p *ContentPack) fillRomanization() {
+	fillVocabularyRomanization(p.Vocabularies)
+}
+
+// source 获取某一部分的来源
+func (p *ContentPack) source(section string) packSource {
+	if src, ok := p.sources[section]; ok {
+		return src
+	}
+	return packSource{file: section, firstRow: 1}
+}
+
+// setSource 设置某一部分的来源
+func (p *ContentPack) setSource(section, file string, firstRow int) {
+	if p.sources == nil {
+		p.sources = make(map[string]packSource)
+	}
+	p.sources[section] = packSource{file: file, firstRow: firstRow}
+}
+
+func loadJSONPack(path string) (*ContentPack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pack ContentPack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("%s: 解析JSON失败: %w", path, err)
+	}
+
+	pack.setSingleFileSources(path)
+	return &pack, nil
+}
+
+func loadYAMLPack(path string) (*ContentPack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pack ContentPack
+	if err := unmarshalYAML(data, &pack); err != nil {
+		return nil, fmt.Errorf("%s: 解析YAML失败: %w", path, err)
+	}
+
+	pack.setSingleFileSources(path)
+	return &pack, nil
+}
+
+// setSingleFileSources 单文件内容包中，行号为记录在列表中的序号
+func (p *ContentPack) setSingleFileSources(path string) {
+	p.setSource(packSectionManifest, path, 0)
+	p.setSource(packSectionRoots, path+"#roots", 1)
+	p.setSource(packSectionVocabularies, path+"#vocabularies", 1)
+	p.setSource(packSectionDialectExamples, path+"#dialect_examples", 1)
+}
+
+// loadCSVPack 加载CSV目录内容包，目录中包含 pack.yaml 和各部分的CSV文件
+func loadCSVPack(dir string) (*ContentPack, error) {
+	var pack ContentPack
+
+	manifest := filepath.Join(dir, packManifestFile)
+	pack.setSource(packSectionManifest, manifest, 0)
+	if data, err := os.ReadFile(manifest); err == nil {
+		if err := unmarshalYAML(data, &pack); err != nil {
+			return nil, fmt.Errorf("%s: 解析YAML失败: %w", manifest, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var errs ValidationErrors
+
+	rootsFile := filepath.Join(dir, packRootsFile)
+	rows, err := readCSVRecords(rootsFile, rootCSVHeader)
+	if err != nil {
+		return nil, err
+	}
+	pack.setSource(packSectionRoots, rootsFile, 2)
+	for _, row := range rows {
+		root := CharacterRoot{
+			ID:            row.int64("id", &errs),
+			Root:          row.get("root"),
+			Pinyin:        row.get("pinyin"),
+			Difficulty:    row.int("difficulty", &errs),
+			Tier:          row.int("tier", &errs),
+			Description:   row.get("description"),
+			MiddleChinese: row.get("middle_chinese"),
+			Traditional:   row.get("traditional"),
+			Japanese:      row.get("japanese"),
+			Korean:        row.get("korean"),
+		}
+		pack.Roots = append(pack.Roots, root)
+	}
+
+	vocabsFile := filepath.Join(dir, packVocabulariesFile)
+	rows, err = readCSVRecords(vocabsFile, vocabularyCSVHeader)
+	if err != nil {
+		return nil, err
+	}
+	pack.setSource(packSectionVocabularies, vocabsFile, 2)
+	for _, row := range rows {
+		vocab := Vocabulary{
+			ID:            row.int64("id", &errs),
+			RootID:        row.int64("root_id", &errs),
+			Language:      row.get("language"),
+			Word:          row.get("word"),
+			Romaji:        row.get("romaji"),
+			Pronunciation: row.get("pronunciation"),
+			Meaning:       row.get("meaning"),
+			ReadType:      row.get("read_type"),
+			Difficulty:    row.int("difficulty", &errs),
+			ExampleCount:  row.int("example_count", &errs),
+			Roots:         row.vocabularyRoots("roots", &errs),
+			Hanja:         row.get("hanja"),
+			Chinese:       row.get("chinese"),
+			Morphemes:     row.get("morphemes"),
+		}
+		pack.Vocabularies = append(pack.Vocabularies, vocab)
+	}
+
+	dialectsFile := filepath.Join(dir, packDialectExampleFile)
+	if _, err := os.Stat(dialectsFile); err == nil {
+		rows, err = readCSVRecords(dialectsFile, dialectExampleCSVHeader)
+		if err != nil {
+			return nil, err
+		}
+		pack.setSource(packSectionDialectExamples, dialectsFile, 2)
+		for _, row := range rows {
+			example := DialectExample{
+				ID:          row.int64("id", &errs),
+				RootID:      row.int64("root_id", &errs),
+				Standard:    row.get("standard"),
+				Dialect:     row.get("dialect"),
+				DialectType: row.get("dialect_type"),
+				Description: row.get("description"),
+				AudioURL:    row.get("audio_url"),
+			}
+			pack.DialectExamples = append(pack.DialectExamples, example)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &pack, nil
+}
+
+// exportCSV 将内容包导出为CSV目录
+func (p *ContentPack) exportCSV(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	manifest, err := marshalYAML(struct {
+		Name      string   `json:"name,omitempty"`
+		Version   string   `json:"version"`
+		Languages []string `json:"languages"`
+	}{p.Name, p.Version, p.Languages})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, packManifestFile), manifest, 0o644); err != nil {
+		return err
+	}
+
+	roots := make([][]string, 0, len(p.Roots))
+	for _, root := range p.Roots {
+		roots = append(roots, []string{
+			strconv.FormatInt(root.ID, 10), root.Root, root.Pinyin,
+			strconv.Itoa(root.Difficulty), strconv.Itoa(root.Tier), root.Description, root.MiddleChinese,
+			root.Traditional, root.Japanese, root.Korean,
+		})
+	}
+	if err := writeCSVRecords(filepath.Join(dir, packRootsFile), rootCSVHeader, roots); err != nil {
+		return err
+	}
+
+	vocabs := make([][]string, 0, len(p.Vocabularies))
+	for _, vocab := range p.Vocabularies {
+		vocabs = append(vocabs, []string{
+			strconv.FormatInt(vocab.ID, 10), strconv.FormatInt(vocab.RootID, 10), vocab.Language,
+			vocab.Word, vocab.Romaji, vocab.Pronunciation, vocab.Meaning, vocab.ReadType,
+			strconv.Itoa(vocab.Difficulty), strconv.Itoa(vocab.ExampleCount), formatVocabularyRoots(vocab.Roots),
+			vocab.Hanja, vocab.Chinese, vocab.Morphemes,
+		})
+	}
+	if err := writeCSVRecords(filepath.Join(dir, packVocabulariesFile), vocabularyCSVHeader, vocabs); err != nil {
+		return err
+	}
+
+	examples := make([][]string, 0, len(p.DialectExamples))
+	for _, example := range p.DialectExamples {
+		examples = append(examples, []string{
+			strconv.FormatInt(example.ID, 10), strconv.FormatInt(example.RootID, 10), example.Standard,
+			example.Dialect, example.DialectType, example.Description, example.AudioURL,
+		})
+	}
+	return writeCSVRecords(filepath.Join(dir, packDialectExampleFile), dialectExampleCSVHeader, examples)
+}
+
+// csvRecord CSV中的一行记录
+type csvRecord struct {
+	file   string
+	row    int
+	values map[string]string
+}
+
+func (r csvRecord) get(field string) string {
+	return strings.TrimSpace(r.values[field])
+}
+
+func (r csvRecord) int64(field string, errs *ValidationErrors) int64 {
+	value := r.get(field)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		*errs = append(*errs, ValidationError{File: r.file, Row: r.row, Field: field,
+			Message: fmt.Sprintf("不是有效的整数: %q", value)})
+	}
+	return n
+}
+
+func (r csvRecord) int(field string, errs *ValidationErrors) int {
+	return int(r.int64(field, errs))
+}
+
+// vocabularyRoots 解析组成字根列，格式为 1:0;2:1
+func (r csvRecord) vocabularyRoots(field string, errs *ValidationErrors) []VocabularyRoot {
+	value := r.get(field)
+	if value == "" {
+		return nil
+	}
+
+	var roots []VocabularyRoot
+	for _, item := range strings.Split(value, ";") {
+		rootID, position, ok := strings.Cut(strings.TrimSpace(item), ":")
+		id, err1 := strconv.ParseInt(strings.TrimSpace(rootID), 10, 64)
+		pos, err2 := strconv.Atoi(strings.TrimSpace(position))
+		if !ok || err1 != nil || err2 != nil {
+			*errs = append(*errs, ValidationError{File: r.file, Row: r.row, Field: field,
+				Message: fmt.Sprintf("组成字根格式应为 字根ID:位置，如 1:0;2:1，实际为 %q", item)})
+			continue
+		}
+		roots = append(roots, VocabularyRoot{RootID: id, Position: pos})
+	}
+	return roots
+}
+
+// formatVocabularyRoots 格式化组成字根列
+func formatVocabularyRoots(roots []VocabularyRoot) string {
+	items := make([]string, len(roots))
+	for i, root := range roots {
+		items[i] = fmt.Sprintf("%d:%d", root.RootID, root.Position)
+	}
+	return strings.Join(items, ";")
+}
+
+// readCSVRecords 读取CSV文件，第一行为表头，缺少必需列时报错
+func readCSVRecords(path string, header []string) ([]csvRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+
+	columns, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: 读取表头失败: %w", path, err)
+	}
+	for i := range columns {
+		columns[i] = strings.TrimSpace(strings.TrimPrefix(columns[i], "\ufeff"))
+	}
+
+	index := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		index[column] = true
+	}
+	for _, column := range header {
+		if !index[column] && !optionalCSVColumns[column] {
+			return nil, ValidationErrors{{File: path, Row: 1, Field: column, Message: "缺少列"}}
+		}
+	}
+
+	var records []csvRecord
+	for row := 2; ; row++ {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: 读取CSV失败: %w", path, row, err)
+		}
+
+		record := csvRecord{file: path, row: row, values: make(map[string]string, len(columns))}
+		for i, column := range columns {
+			if i < len(values) {
+				record.values[column] = values[i]
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// writeCSVRecords 写入CSV文件
+func writeCSVRecords(path string, header []string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	writer := csv.NewWriter(f)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// marshalYAML 先按 json 标签序列化再转为YAML，保持与JSON一致的字段名
+func marshalYAML(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// unmarshalYAML 将YAML转为JSON后按 json 标签解析
+func unmarshalYAML(data []byte, v any) error {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(normalizeYAML(doc))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// normalizeYAML 将YAML解析出的 map[interface{}]interface{} 转为可被JSON序列化的结构
+func normalizeYAML(v any) any {
+	switch val := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = normalizeYAML(item)
+		}
+		return m
+	case []any:
+		for i, item := range val {
+			val[i] = normalizeYAML(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
@@ -0,0 +1,337 @@
+package hanbao
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ValidationError 内容校验错误，定位到文件、行和字段
+type ValidationError struct {
+	File    string `json:"file"`
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error 实现 error 接口
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s:%d %s: %s", e.File, e.Row, e.Field, e.Message)
+}
+
+// ValidationErrors 多个内容校验错误
+type ValidationErrors []ValidationError
+
+// Error 实现 error 接口
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("内容校验失败（%d处错误）:\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+// validReadTypes 合法的读音类型
+var validReadTypes = map[string]bool{
+	"":    true,
+	"on":  true,
+	"kun": true,
+}
+
+// Validate 校验内容包，返回 ValidationErrors
+func (p *ContentPack) Validate() error {
+	var errs ValidationErrors
+
+	if strings.TrimSpace(p.Version) == "" {
+		errs = append(errs, ValidationError{File: p.source(packSectionManifest).file, Field: "version", Message: "内容包缺少版本号"})
+	}
+
+	languages := make(map[string]bool, len(p.Languages))
+	for _, language := range p.Languages {
+		languages[language] = true
+	}
+
+	// 校验字根
+	src := p.source(packSectionRoots)
+	rootIDs := make(map[int64]bool, len(p.Roots))
+	rootChars := make(map[string]bool, len(p.Roots))
+	rootForms := make(map[string]int64, len(p.Roots))
+	for i, root := range p.Roots {
+		report := errorReporter(&errs, src, i)
+
+		if root.ID <= 0 {
+			report("id", "ID必须为正整数")
+		} else if rootIDs[root.ID] {
+			report("id", fmt.Sprintf("重复的字根ID: %d", root.ID))
+		}
+		rootIDs[root.ID] = true
+
+		if root.Root == "" {
+			report("root", "字根不能为空")
+		} else if rootChars[root.Root] {
+			report("root", fmt.Sprintf("重复的字根: %s", root.Root))
+		}
+		rootChars[root.Root] = true
+
+		if root.Difficulty < 1 || root.Difficulty > 3 {
+			report("difficulty", fmt.Sprintf("难度必须在1-3之间: %d", root.Difficulty))
+		}
+		if root.Tier < 1 || root.Tier > 3 {
+			report("tier", fmt.Sprintf("层级必须在1-3之间: %d", root.Tier))
+		}
+		validateRootVariants(root, rootForms, report)
+		if root.MiddleChinese != "" {
+			if _, ok := parseMiddleChinese(root.MiddleChinese); !ok {
+				report("middle_chinese", fmt.Sprintf("无法识别的中古汉语拟音: %s（应使用白一平转写，如 denH）", root.MiddleChinese))
+			}
+		}
+	}
+
+	// 校验词汇
+	src = p.source(packSectionVocabularies)
+	vocabIDs := make(map[int64]bool, len(p.Vocabularies))
+	for i, vocab := range p.Vocabularies {
+		report := errorReporter(&errs, src, i)
+
+		if vocab.ID <= 0 {
+			report("id", "ID必须为正整数")
+		} else if vocabIDs[vocab.ID] {
+			report("id", fmt.Sprintf("重复的词汇ID: %d", vocab.ID))
+		}
+		vocabIDs[vocab.ID] = true
+
+		if !rootIDs[vocab.RootID] {
+			report("root_id", fmt.Sprintf("引用了不存在的字根: %d", vocab.RootID))
+		}
+		validateVocabularyRoots(vocab, rootIDs, report)
+
+		if vocab.Language == "" {
+			report("language", "语言不能为空")
+		} else if len(languages) > 0 && !languages[vocab.Language] {
+			report("language", fmt.Sprintf("语言 %s 不在内容包声明的语言列表中", vocab.Language))
+		}
+
+		if vocab.Word == "" {
+			report("word", "词汇不能为空")
+		}
+		if vocab.Language == "ja" && vocab.Romaji == "" {
+			report("romaji", "日语词汇必须填写罗马字")
+		}
+		validateVocabularyRomanization(vocab, report)
+		validateVocabularyHanja(vocab, report)
+		validateVocabularyMorphemes(vocab, report)
+		validateVocabularyHanViet(vocab, report)
+		for _, r := range vocab.Chinese {
+			if !unicode.Is(unicode.Han, r) {
+				report("chinese", fmt.Sprintf("不是汉字: %q", r))
+				break
+			}
+		}
+
+		if !validReadTypes[vocab.ReadType] {
+			report("read_type", fmt.Sprintf("无效的读音类型: %s（只能是 on 或 kun）", vocab.ReadType))
+		} else if vocab.ReadType != "" && vocab.Language != "ja" {
+			report("read_type", "只有日语词汇可以设置读音类型")
+		}
+
+		if vocab.Difficulty < 1 || vocab.Difficulty > 3 {
+			report("difficulty", fmt.Sprintf("难度必须在1-3之间: %d", vocab.Difficulty))
+		}
+	}
+
+	// 校验方言示例
+	src = p.source(packSectionDialectExamples)
+	exampleIDs := make(map[int64]bool, len(p.DialectExamples))
+	for i, example := range p.DialectExamples {
+		report := errorReporter(&errs, src, i)
+
+		if example.ID <= 0 {
+			report("id", "ID必须为正整数")
+		} else if exampleIDs[example.ID] {
+			report("id", fmt.Sprintf("重复的方言示例ID: %d", example.ID))
+		}
+		exampleIDs[example.ID] = true
+
+		if !rootIDs[example.RootID] {
+			report("root_id", fmt.Sprintf("引用了不存在的字根: %d", example.RootID))
+		}
+		if example.Standard == "" {
+			report("standard", "标准汉语不能为空")
+		}
+		if example.Dialect == "" {
+			report("dialect", "方言不能为空")
+		}
+		if example.DialectType == "" {
+			report("dialect_type", "方言类型不能为空")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateRootVariants 校验字根的繁体、日本、韩国写法：必须是单个汉字，且不能与其他字根的写法相同
+func validateRootVariants(root CharacterRoot, rootForms map[string]int64, report func(field, message string)) {
+	fields := []struct {
+		name, form string
+	}{
+		{"traditional", root.Traditional},
+		{"japanese", root.Japanese},
+		{"korean", root.Korean},
+	}
+	for _, f := range fields {
+		if f.form == "" {
+			continue
+		}
+		if r := []rune(f.form); len(r) != 1 || !IsHanIdeograph(r[0]) {
+			report(f.name, fmt.Sprintf("必须是单个汉字: %s", f.form))
+		}
+	}
+
+	for _, form := range root.Forms() {
+		if id, exists := rootForms[form]; exists && id != root.ID {
+			report("root", fmt.Sprintf("字形 %s 与字根 %d 的写法重复", form, id))
+		}
+		rootForms[form] = root.ID
+	}
+}
+
+// validateVocabularyRoots 校验词汇的组成字根
+func validateVocabularyRoots(vocab Vocabulary, rootIDs map[int64]bool, report func(field, message string)) {
+	if len(vocab.Roots) == 0 {
+		return
+	}
+
+	wordLen := utf8.RuneCountInString(vocab.Word)
+	hasPrimary := false
+	seenRoots := make(map[int64]bool, len(vocab.Roots))
+	lastPosition := -1
+	for _, component := range vocab.Roots {
+		if !rootIDs[component.RootID] {
+			report("roots", fmt.Sprintf("引用了不存在的字根: %d", component.RootID))
+		}
+		if seenRoots[component.RootID] {
+			report("roots", fmt.Sprintf("重复的组成字根: %d", component.RootID))
+		}
+		seenRoots[component.RootID] = true
+
+		if component.Position < 0 || component.Position >= wordLen {
+			report("roots", fmt.Sprintf("字根 %d 的位置 %d 超出词语长度", component.RootID, component.Position))
+		} else if component.Position <= lastPosition {
+			report("roots", "组成字根必须按位置从小到大排列")
+		}
+		lastPosition = component.Position
+
+		if component.RootID == vocab.RootID {
+			hasPrimary = true
+		}
+	}
+
+	if !hasPrimary {
+		report("roots", fmt.Sprintf("组成字根中缺少主字根 %d", vocab.RootID))
+	}
+}
+
+// validateVocabularyRomanization 交叉校验罗马字：日语罗马字应与假名读音的黑本式转写一致，韩语发音应与谚文的逐音节转写一致
+func validateVocabularyRomanization(vocab Vocabulary, report func(field, message string)) {
+	field, want, ok := vocab.RomanizedField()
+	if !ok {
+		return
+	}
+	got := vocab.Romaji
+	if field == "pronunciation" {
+		got = vocab.Pronunciation
+	}
+	if got != "" && !strings.EqualFold(got, want) {
+		report(field, fmt.Sprintf("%s 与自动转写的 %s 不一致", got, want))
+	}
+}
+
+// validateVocabularyHanja 校验韩语汉字词的汉字标注，汉字必须与谚文音节一一对应
+func validateVocabularyHanja(vocab Vocabulary, report func(field, message string)) {
+	if vocab.Hanja == "" {
+		return
+	}
+	if vocab.Language != "ko" {
+		report("hanja", "只有韩语词汇可以标注汉字")
+		return
+	}
+
+	if syllables, chars := utf8.RuneCountInString(vocab.Word), utf8.RuneCountInString(vocab.Hanja); syllables != chars {
+		report("hanja", fmt.Sprintf("汉字数量 %d 与音节数量 %d 不一致", chars, syllables))
+	}
+	for _, r := range vocab.Hanja {
+		if !unicode.Is(unicode.Han, r) {
+			report("hanja", fmt.Sprintf("不是汉字: %q", r))
+		}
+	}
+}
+
+// validateVocabularyMorphemes 校验词素标注：词素拼起来应与词汇一致，位置必须在中文词的范围内且不能重复
+func validateVocabularyMorphemes(vocab Vocabulary, report func(field, message string)) {
+	if vocab.Morphemes == "" {
+		return
+	}
+	if vocab.Chinese == "" {
+		report("morphemes", "标注词素时必须填写对应的中文词")
+		return
+	}
+
+	parts := strings.Split(vocab.Morphemes, "+")
+	seen := make(map[int]bool)
+	var joined strings.Builder
+	for i, m := range vocab.SplitMorphemes() {
+		if m.Text == "" {
+			report("morphemes", "词素不能为空")
+		}
+		joined.WriteString(m.Text)
+
+		if m.Position < 0 {
+			if strings.Contains(parts[i], ":") {
+				report("morphemes", fmt.Sprintf("词素 %s 的位置超出中文词 %s 的范围", m.Text, vocab.Chinese))
+			}
+			continue
+		}
+		if seen[m.Position] {
+			report("morphemes", fmt.Sprintf("多个词素对应同一个汉字: %s", m.Chinese))
+		}
+		seen[m.Position] = true
+	}
+
+	if normalizeRomaji(joined.String()) != normalizeRomaji(vocab.Word) {
+		report("morphemes", fmt.Sprintf("词素拼起来是 %s，与词汇 %s 不一致", joined.String(), vocab.Word))
+	}
+}
+
+// validateVocabularyHanViet 校验越南语词汇：必须使用预组合字符（NFC），填写了中文词时音节必须与汉字一一对应
+func validateVocabularyHanViet(vocab Vocabulary, report func(field, message string)) {
+	if vocab.Language != "vi" {
+		return
+	}
+	if !norm.NFC.IsNormalString(vocab.Word) {
+		report("word", "越南语词汇必须使用预组合字符（NFC），如 ệ 不能写成 e + 两个组合符号")
+	}
+	if vocab.Chinese == "" {
+		return
+	}
+	if syllables, chars := len(strings.Fields(vocab.Word)), utf8.RuneCountInString(vocab.Chinese); syllables != chars {
+		report("chinese", fmt.Sprintf("汉字数量 %d 与音节数量 %d 不一致", chars, syllables))
+	}
+}
+
+// errorReporter 返回记录第 index 条记录校验错误的函数
+func errorReporter(errs *ValidationErrors, src packSource, index int) func(field, message string) {
+	return func(field, message string) {
+		*errs = append(*errs, ValidationError{
+			File:    src.file,
+			Row:     src.firstRow + index,
+			Field:   field,
+			Message: message,
+		})
+	}
+}
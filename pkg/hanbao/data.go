@@ -5,87 +5,109 @@ import "time"
 // Predefined character roots with their vocabulary
 var CharacterRootsData = []CharacterRoot{
 	// Tier 1 - High priority roots
-	{ID: 1, Root: "电", Pinyin: "diàn", Difficulty: 1, Tier: 1, Description: "电力、电子相关", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 2, Root: "话", Pinyin: "huà", Difficulty: 1, Tier: 1, Description: "言语、对话", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 3, Root: "学", Pinyin: "xué", Difficulty: 1, Tier: 1, Description: "学习、教育", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 4, Root: "生", Pinyin: "shēng", Difficulty: 1, Tier: 1, Description: "生命、生产", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 5, Root: "国", Pinyin: "guó", Difficulty: 1, Tier: 1, Description: "国家、国际", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 6, Root: "家", Pinyin: "jiā", Difficulty: 1, Tier: 1, Description: "家庭、家居", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 1, Root: "电", Pinyin: "diàn", MiddleChinese: "denH", Traditional: "電", Japanese: "電", Korean: "電", Difficulty: 1, Tier: 1, Description: "电力、电子相关", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 2, Root: "话", Pinyin: "huà", MiddleChinese: "hwaejH", Traditional: "話", Japanese: "話", Korean: "話", Difficulty: 1, Tier: 1, Description: "言语、对话", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 3, Root: "学", Pinyin: "xué", MiddleChinese: "haewk", Traditional: "學", Korean: "學", Difficulty: 1, Tier: 1, Description: "学习、教育", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 4, Root: "生", Pinyin: "shēng", MiddleChinese: "sraeng", Difficulty: 1, Tier: 1, Description: "生命、生产", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 5, Root: "国", Pinyin: "guó", MiddleChinese: "kwok", Traditional: "國", Korean: "國", Difficulty: 1, Tier: 1, Description: "国家、国际", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 6, Root: "家", Pinyin: "jiā", MiddleChinese: "kae", Difficulty: 1, Tier: 1, Description: "家庭、家居", CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// Tier 2 - Medium priority roots
-	{ID: 7, Root: "发", Pinyin: "fā", Difficulty: 2, Tier: 2, Description: "发出、发展", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 8, Root: "现", Pinyin: "xiàn", Difficulty: 2, Tier: 2, Description: "显现、现在", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 9, Root: "图", Pinyin: "tú", Difficulty: 2, Tier: 2, Description: "图画、地图", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 10, Root: "书", Pinyin: "shū", Difficulty: 2, Tier: 2, Description: "书籍、书写", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 11, Root: "馆", Pinyin: "guǎn", Difficulty: 2, Tier: 2, Description: "馆舍、场所", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 12, Root: "文", Pinyin: "wén", Difficulty: 2, Tier: 2, Description: "文字、文化", CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 13, Root: "化", Pinyin: "huà", Difficulty: 2, Tier: 2, Description: "变化、化学", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 7, Root: "发", Pinyin: "fā", MiddleChinese: "pjot", Traditional: "發", Japanese: "発", Korean: "發", Difficulty: 2, Tier: 2, Description: "发出、发展", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 8, Root: "现", Pinyin: "xiàn", MiddleChinese: "henH", Traditional: "現", Japanese: "現", Korean: "現", Difficulty: 2, Tier: 2, Description: "显现、现在", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 9, Root: "图", Pinyin: "tú", MiddleChinese: "du", Traditional: "圖", Japanese: "図", Korean: "圖", Difficulty: 2, Tier: 2, Description: "图画、地图", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 10, Root: "书", Pinyin: "shū", MiddleChinese: "syo", Traditional: "書", Japanese: "書", Korean: "書", Difficulty: 2, Tier: 2, Description: "书籍、书写", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 11, Root: "馆", Pinyin: "guǎn", MiddleChinese: "kwanX", Traditional: "館", Japanese: "館", Korean: "館", Difficulty: 2, Tier: 2, Description: "馆舍、场所", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 12, Root: "文", Pinyin: "wén", MiddleChinese: "mjun", Difficulty: 2, Tier: 2, Description: "文字、文化", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 13, Root: "化", Pinyin: "huà", MiddleChinese: "xwaeH", Difficulty: 2, Tier: 2, Description: "变化、化学", CreatedAt: time.Now(), UpdatedAt: time.Now()},
 }
 
-var VocabularyData = []Vocabulary{
+// 复合词只收录一次，通过 Roots 关联到所有组成字根（如 電話 同时属于 电 和 话）；
+// 韩语词汇的发音和逐音节读音由谚文自动转写，见 fillVocabularyRomanization
+var VocabularyData = fillVocabularyRomanization([]Vocabulary{
 	// 电 (diàn) - Japanese examples
-	{ID: 1, RootID: 1, Language: "ja", Word: "電話", Romaji: "denwa", Pronunciation: "でんわ", Meaning: "telephone", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 2, RootID: 1, Language: "ja", Word: "電気", Romaji: "denki", Pronunciation: "でんき", Meaning: "electricity", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 3, RootID: 1, Language: "ja", Word: "電車", Romaji: "densha", Pronunciation: "でんしゃ", Meaning: "train", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 4, RootID: 1, Language: "ja", Word: "電池", Romaji: "denchi", Pronunciation: "でんち", Meaning: "battery", ReadType: "on", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 1, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}, {RootID: 2, Position: 1}}, Language: "ja", Word: "電話", Chinese: "电话", Romaji: "denwa", Pronunciation: "でんわ", Meaning: "telephone", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 2, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "ja", Word: "電気", Chinese: "电气", Romaji: "denki", Pronunciation: "でんき", Meaning: "electricity", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 3, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "ja", Word: "電車", Chinese: "电车", Romaji: "densha", Pronunciation: "でんしゃ", Meaning: "train", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 4, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "ja", Word: "電池", Chinese: "电池", Romaji: "denchi", Pronunciation: "でんち", Meaning: "battery", ReadType: "on", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 电 (diàn) - Korean examples
-	{ID: 5, RootID: 1, Language: "ko", Word: "전화", Pronunciation: "jeon-hwa", Meaning: "telephone", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 6, RootID: 1, Language: "ko", Word: "전기", Pronunciation: "jeon-gi", Meaning: "electricity", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 7, RootID: 1, Language: "ko", Word: "전철", Pronunciation: "jeon-cheol", Meaning: "electric train", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 5, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}, {RootID: 2, Position: 1}}, Language: "ko", Word: "전화", Hanja: "電話", Chinese: "电话", Meaning: "telephone", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 6, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "ko", Word: "전기", Hanja: "電氣", Chinese: "电气", Meaning: "electricity", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 7, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "ko", Word: "전철", Hanja: "電鐵", Chinese: "电铁", Meaning: "electric train", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 话 (huà) - Japanese examples
-	{ID: 8, RootID: 2, Language: "ja", Word: "会話", Romaji: "kaiwa", Pronunciation: "かいわ", Meaning: "conversation", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 9, RootID: 2, Language: "ja", Word: "電話", Romaji: "denwa", Pronunciation: "でんわ", Meaning: "telephone", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 8, RootID: 2, Roots: []VocabularyRoot{{RootID: 2, Position: 1}}, Language: "ja", Word: "会話", Chinese: "会话", Romaji: "kaiwa", Pronunciation: "かいわ", Meaning: "conversation", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 话 (huà) - Korean examples
-	{ID: 10, RootID: 2, Language: "ko", Word: "대화", Pronunciation: "dae-hwa", Meaning: "conversation", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 11, RootID: 2, Language: "ko", Word: "전화", Pronunciation: "jeon-hwa", Meaning: "telephone", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 10, RootID: 2, Roots: []VocabularyRoot{{RootID: 2, Position: 1}}, Language: "ko", Word: "대화", Hanja: "對話", Chinese: "对话", Meaning: "conversation", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 学 (xué) - Japanese examples
-	{ID: 12, RootID: 3, Language: "ja", Word: "学生", Romaji: "gakusei", Pronunciation: "がくせい", Meaning: "student", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 13, RootID: 3, Language: "ja", Word: "学校", Romaji: "gakkou", Pronunciation: "がっこう", Meaning: "school", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 14, RootID: 3, Language: "ja", Word: "大学", Romaji: "daigaku", Pronunciation: "だいがく", Meaning: "university", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 15, RootID: 3, Language: "ja", Word: "学習", Romaji: "gakushuu", Pronunciation: "がくしゅう", Meaning: "study/learning", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 12, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}, {RootID: 4, Position: 1}}, Language: "ja", Word: "学生", Chinese: "学生", Romaji: "gakusei", Pronunciation: "がくせい", Meaning: "student", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 13, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}}, Language: "ja", Word: "学校", Chinese: "学校", Romaji: "gakkou", Pronunciation: "がっこう", Meaning: "school", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 14, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 1}}, Language: "ja", Word: "大学", Chinese: "大学", Romaji: "daigaku", Pronunciation: "だいがく", Meaning: "university", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 15, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}}, Language: "ja", Word: "学習", Chinese: "学习", Romaji: "gakushuu", Pronunciation: "がくしゅう", Meaning: "study/learning", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 学 (xué) - Korean examples
-	{ID: 16, RootID: 3, Language: "ko", Word: "학생", Pronunciation: "hak-saeng", Meaning: "student", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 17, RootID: 3, Language: "ko", Word: "학교", Pronunciation: "hak-gyo", Meaning: "school", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 18, RootID: 3, Language: "ko", Word: "대학", Pronunciation: "dae-hak", Meaning: "university", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 16, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}, {RootID: 4, Position: 1}}, Language: "ko", Word: "학생", Hanja: "學生", Chinese: "学生", Meaning: "student", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 17, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}}, Language: "ko", Word: "학교", Hanja: "學校", Chinese: "学校", Meaning: "school", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 18, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 1}}, Language: "ko", Word: "대학", Hanja: "大學", Chinese: "大学", Meaning: "university", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 生 (shēng) - Japanese examples
-	{ID: 19, RootID: 4, Language: "ja", Word: "学生", Romaji: "gakusei", Pronunciation: "がくせい", Meaning: "student", ReadType: "on", Difficulty: 1, ExampleCount: 4, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 20, RootID: 4, Language: "ja", Word: "生活", Romaji: "seikatsu", Pronunciation: "せいかつ", Meaning: "life/lifestyle", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 21, RootID: 4, Language: "ja", Word: "生命", Romaji: "seimei", Pronunciation: "せいめい", Meaning: "life", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 22, RootID: 4, Language: "ja", Word: "生物", Romaji: "seibutsu", Pronunciation: "せいぶつ", Meaning: "living things", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 23, RootID: 4, Language: "ja", Word: "生鮮", Romaji: "seisen", Pronunciation: "せいせん", Meaning: "fresh food", ReadType: "on", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 20, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "ja", Word: "生活", Chinese: "生活", Romaji: "seikatsu", Pronunciation: "せいかつ", Meaning: "life/lifestyle", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 21, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "ja", Word: "生命", Chinese: "生命", Romaji: "seimei", Pronunciation: "せいめい", Meaning: "life", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 22, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "ja", Word: "生物", Chinese: "生物", Romaji: "seibutsu", Pronunciation: "せいぶつ", Meaning: "living things", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 23, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "ja", Word: "生鮮", Chinese: "生鲜", Romaji: "seisen", Pronunciation: "せいせん", Meaning: "fresh food", ReadType: "on", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 生 (shēng) - Korean examples
-	{ID: 24, RootID: 4, Language: "ko", Word: "학생", Pronunciation: "hak-saeng", Meaning: "student", Difficulty: 1, ExampleCount: 4, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 25, RootID: 4, Language: "ko", Word: "생활", Pronunciation: "saeng-hwal", Meaning: "life/lifestyle", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 26, RootID: 4, Language: "ko", Word: "생명", Pronunciation: "saeng-myeong", Meaning: "life", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 27, RootID: 4, Language: "ko", Word: "생물", Pronunciation: "saeng-mul", Meaning: "living things", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 25, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "ko", Word: "생활", Hanja: "生活", Chinese: "生活", Meaning: "life/lifestyle", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 26, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "ko", Word: "생명", Hanja: "生命", Chinese: "生命", Meaning: "life", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 27, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "ko", Word: "생물", Hanja: "生物", Chinese: "生物", Meaning: "living things", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 国 (guó) - Japanese examples
-	{ID: 28, RootID: 5, Language: "ja", Word: "中国", Romaji: "chuugoku", Pronunciation: "ちゅうごく", Meaning: "China", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 29, RootID: 5, Language: "ja", Word: "外国", Romaji: "gaikoku", Pronunciation: "がいこく", Meaning: "foreign country", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 30, RootID: 5, Language: "ja", Word: "国際", Romaji: "kokusai", Pronunciation: "こくさい", Meaning: "international", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 28, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 1}}, Language: "ja", Word: "中国", Chinese: "中国", Romaji: "chuugoku", Pronunciation: "ちゅうごく", Meaning: "China", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 29, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 1}}, Language: "ja", Word: "外国", Chinese: "外国", Romaji: "gaikoku", Pronunciation: "がいこく", Meaning: "foreign country", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 30, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 0}}, Language: "ja", Word: "国際", Chinese: "国际", Romaji: "kokusai", Pronunciation: "こくさい", Meaning: "international", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 国 (guó) - Korean examples
-	{ID: 31, RootID: 5, Language: "ko", Word: "중국", Pronunciation: "jung-guk", Meaning: "China", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 32, RootID: 5, Language: "ko", Word: "외국", Pronunciation: "oe-guk", Meaning: "foreign country", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 33, RootID: 5, Language: "ko", Word: "국제", Pronunciation: "guk-je", Meaning: "international", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 31, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 1}}, Language: "ko", Word: "중국", Hanja: "中國", Chinese: "中国", Meaning: "China", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 32, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 1}}, Language: "ko", Word: "외국", Hanja: "外國", Chinese: "外国", Meaning: "foreign country", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 33, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 0}}, Language: "ko", Word: "국제", Hanja: "國際", Chinese: "国际", Meaning: "international", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 家 (jiā) - Japanese examples
-	{ID: 34, RootID: 6, Language: "ja", Word: "家庭", Romaji: "katei", Pronunciation: "かてい", Meaning: "family", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 35, RootID: 6, Language: "ja", Word: "家", Romaji: "ie", Pronunciation: "いえ", Meaning: "home/house", ReadType: "kun", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 36, RootID: 6, Language: "ja", Word: "家族", Romaji: "kazoku", Pronunciation: "かぞく", Meaning: "family", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 34, RootID: 6, Roots: []VocabularyRoot{{RootID: 6, Position: 0}}, Language: "ja", Word: "家庭", Chinese: "家庭", Romaji: "katei", Pronunciation: "かてい", Meaning: "family", ReadType: "on", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 35, RootID: 6, Roots: []VocabularyRoot{{RootID: 6, Position: 0}}, Language: "ja", Word: "家", Chinese: "家", Romaji: "ie", Pronunciation: "いえ", Meaning: "home/house", ReadType: "kun", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 36, RootID: 6, Roots: []VocabularyRoot{{RootID: 6, Position: 0}}, Language: "ja", Word: "家族", Chinese: "家族", Romaji: "kazoku", Pronunciation: "かぞく", Meaning: "family", ReadType: "on", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 
 	// 家 (jiā) - Korean examples
-	{ID: 37, RootID: 6, Language: "ko", Word: "가족", Pronunciation: "ga-jok", Meaning: "family", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 38, RootID: 6, Language: "ko", Word: "가정", Pronunciation: "ga-jeong", Meaning: "home/family", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-	{ID: 39, RootID: 6, Language: "ko", Word: "집", Pronunciation: "jip", Meaning: "home/house", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-}
+	{ID: 37, RootID: 6, Roots: []VocabularyRoot{{RootID: 6, Position: 0}}, Language: "ko", Word: "가족", Hanja: "家族", Chinese: "家族", Meaning: "family", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 38, RootID: 6, Roots: []VocabularyRoot{{RootID: 6, Position: 0}}, Language: "ko", Word: "가정", Hanja: "家庭", Chinese: "家庭", Meaning: "home/family", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 39, RootID: 6, Roots: []VocabularyRoot{{RootID: 6, Position: 0}}, Language: "ko", Word: "집", Meaning: "home/house", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+
+	// 英语：按词素与汉字对应，Position 是对应的汉字在中文词中的位置
+	{ID: 40, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}, {RootID: 2, Position: 1}}, Language: "en", Word: "telephone", Chinese: "电话", Morphemes: "tele:0+phone:1", Pronunciation: "/ˈtelɪfəʊn/", Meaning: "telephone", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 41, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "en", Word: "electricity", Chinese: "电气", Morphemes: "electric:0+ity", Pronunciation: "/ɪˌlekˈtrɪsəti/", Meaning: "electricity", Difficulty: 2, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 42, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "en", Word: "electric train", Chinese: "电车", Morphemes: "electric:0+train:1", Pronunciation: "/ɪˈlektrɪk treɪn/", Meaning: "train", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 43, RootID: 2, Roots: []VocabularyRoot{{RootID: 2, Position: 1}}, Language: "en", Word: "dialogue", Chinese: "对话", Morphemes: "dia:0+logue:1", Pronunciation: "/ˈdaɪəlɒɡ/", Meaning: "conversation", Difficulty: 2, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 44, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}, {RootID: 4, Position: 1}}, Language: "en", Word: "student", Chinese: "学生", Morphemes: "stud:0+ent:1", Pronunciation: "/ˈstjuːdnt/", Meaning: "student", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 45, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 0}}, Language: "en", Word: "international", Chinese: "国际", Morphemes: "inter:1+nation:0+al", Pronunciation: "/ˌɪntəˈnæʃnəl/", Meaning: "international", Difficulty: 2, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+
+	// 越南语：汉越词，音节以空格分隔，与中文词的汉字一一对应
+	{ID: 46, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}, {RootID: 2, Position: 1}}, Language: "vi", Word: "điện thoại", Chinese: "电话", Meaning: "telephone", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 47, RootID: 1, Roots: []VocabularyRoot{{RootID: 1, Position: 0}}, Language: "vi", Word: "điện tử", Chinese: "电子", Meaning: "electronic", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 48, RootID: 2, Roots: []VocabularyRoot{{RootID: 2, Position: 1}}, Language: "vi", Word: "hội thoại", Chinese: "会话", Meaning: "conversation", Difficulty: 2, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 49, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}, {RootID: 4, Position: 1}}, Language: "vi", Word: "học sinh", Chinese: "学生", Meaning: "student", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 50, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 1}}, Language: "vi", Word: "đại học", Chinese: "大学", Meaning: "university", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 51, RootID: 3, Roots: []VocabularyRoot{{RootID: 3, Position: 0}}, Language: "vi", Word: "học tập", Chinese: "学习", Meaning: "study", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 52, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "vi", Word: "sinh hoạt", Chinese: "生活", Meaning: "life/lifestyle", Difficulty: 2, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 53, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "vi", Word: "sinh mệnh", Chinese: "生命", Meaning: "life", Difficulty: 2, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 54, RootID: 4, Roots: []VocabularyRoot{{RootID: 4, Position: 0}}, Language: "vi", Word: "sinh vật", Chinese: "生物", Meaning: "living things", Difficulty: 1, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 55, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 1}}, Language: "vi", Word: "Trung Quốc", Chinese: "中国", Meaning: "China", Difficulty: 1, ExampleCount: 3, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 56, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 1}}, Language: "vi", Word: "ngoại quốc", Chinese: "外国", Meaning: "foreign country", Difficulty: 2, ExampleCount: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 57, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 0}}, Language: "vi", Word: "quốc tế", Chinese: "国际", Meaning: "international", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 58, RootID: 5, Roots: []VocabularyRoot{{RootID: 5, Position: 0}, {RootID: 6, Position: 1}}, Language: "vi", Word: "quốc gia", Chinese: "国家", Meaning: "country/nation", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	{ID: 59, RootID: 6, Roots: []VocabularyRoot{{RootID: 6, Position: 0}}, Language: "vi", Word: "gia đình", Chinese: "家庭", Meaning: "family", Difficulty: 1, ExampleCount: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+})
 
 // Dialect examples for level 3 challenges
 var DialectExamplesData = []DialectExample{
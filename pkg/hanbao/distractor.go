@@ -0,0 +1,446 @@
+package hanbao
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// 干扰项来源
+const (
+	DistractorWrongReadType = "wrong_read_type"          // 同一字根的另一种读法，如音读词混入训读
+	DistractorSameMeaning   = "same_meaning"             // 意思相同的另一个词
+	DistractorSimilarRomaji = "similar_romaji"           // 拼写相近的其他词，或长音、促音的常见混淆
+	DistractorOtherLanguage = "same_root_other_language" // 同一字根在另一种语言中的读音
+	DistractorDialectType   = "dialect_type"             // 其他方言
+	DistractorSameHangul    = "same_hangul"              // 谚文读音相同的其他汉字，如 대 可以是 大 也可以是 對
+	DistractorSameWord      = "same_word"                // 同一个词中其他音节的汉字
+	DistractorOtherHanja    = "other_hanja"              // 其他韩语汉字词中的汉字
+	DistractorOtherMorpheme = "other_morpheme"           // 其他词中对应不同汉字的词素
+	DistractorSameRoot      = "same_root"                // 包含同一个字根的其他中文词
+	DistractorOtherConcept  = "other_concept"            // 其他中文词
+	DistractorToneMark      = "tone_mark"                // 声调不同的同一个越南语音节，如 thoại / thoài
+	DistractorOtherSyllable = "other_syllable"           // 其他越南语汉越词中的音节
+	DistractorWrongFinal    = "wrong_final"              // 换成不符合读音对应规律的韵尾，如 學 hak → hal、hap
+	DistractorOtherRoot     = "other_root"               // 其他字根在同一语言中的读音
+	DistractorOtherVariant  = "other_variant"            // 同一字根在其他地区的写法，如 発 / 發 / 发
+	DistractorRegionVariant = "region_variant"           // 其他字根在同一地区的写法，如问 電 时的 話
+)
+
+// similarRomajiLimit 拼写相近的词最多取几个
+const similarRomajiLimit = 3
+
+// Distractor 选择题的干扰项
+type Distractor struct {
+	Text   string
+	Source string
+}
+
+// dialectNames 常见方言的中文名称，也作为方言题数据不足时的补充干扰项
+var dialectNames = []struct {
+	Type string
+	Name string
+}{
+	{"cantonese", "粤语"},
+	{"minnan", "闽南语"},
+	{"wu", "吴语"},
+	{"hakka", "客家话"},
+	{"xiang", "湘语"},
+	{"gan", "赣语"},
+	{"jin", "晋语"},
+}
+
+// DialectName 方言类型的中文名称，未知类型原样返回
+func DialectName(dialectType string) string {
+	for _, d := range dialectNames {
+		if d.Type == dialectType {
+			return d.Name
+		}
+	}
+	return dialectType
+}
+
+// DistractorGenerator 根据词汇之间的关系构造似是而非的干扰项
+type DistractorGenerator struct {
+	repo ContentRepository
+}
+
+// NewDistractorGenerator 创建干扰项生成器
+func NewDistractorGenerator(repo ContentRepository) *DistractorGenerator {
+	return &DistractorGenerator{repo: repo}
+}
+
+// ReadingDistractors 为词汇的罗马字读音构造干扰项，按来源的迷惑程度排列；
+// exclude 中的词汇（如题目中已经给出读音的词）不作为干扰项
+func (g *DistractorGenerator) ReadingDistractors(target Vocabulary, exclude map[int64]bool) ([]Distractor, error) {
+	vocabs, err := g.repo.ListVocabularies()
+	if err != nil {
+		return nil, err
+	}
+
+	answer := romanized(target)
+	var wrongReadType, sameMeanings []Distractor
+	var otherLanguage []otherLanguageReading
+	var similar []similarReading
+	for _, v := range vocabs {
+		if v.ID == target.ID || exclude[v.ID] {
+			continue
+		}
+		text := romanized(v)
+		if text == "" || text == answer {
+			continue
+		}
+
+		if v.Language != target.Language {
+			if isSinoxenic(v.Language) && sharesRoot(v, target) {
+				otherLanguage = append(otherLanguage, otherLanguageReading{
+					Distractor: Distractor{Text: text, Source: DistractorOtherLanguage},
+					cognate:    sameMeaning(v.Meaning, target.Meaning),
+				})
+			}
+			continue
+		}
+
+		switch {
+		case sharesRoot(v, target) && v.ReadType != "" && target.ReadType != "" && v.ReadType != target.ReadType:
+			wrongReadType = append(wrongReadType, Distractor{Text: text, Source: DistractorWrongReadType})
+		case v.Word != target.Word && sameMeaning(v.Meaning, target.Meaning):
+			sameMeanings = append(sameMeanings, Distractor{Text: text, Source: DistractorSameMeaning})
+		default:
+			if d := editDistance(text, answer); d <= max(2, len(answer)/3) {
+				similar = append(similar, similarReading{text: text, distance: d})
+			}
+		}
+	}
+
+	// 另一种语言中意思相同的同源词最容易混淆，排在前面
+	sort.SliceStable(otherLanguage, func(i, j int) bool { return otherLanguage[i].cognate && !otherLanguage[j].cognate })
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].distance < similar[j].distance })
+	var similarRomaji []Distractor
+	for _, s := range similar[:min(len(similar), similarRomajiLimit)] {
+		similarRomaji = append(similarRomaji, Distractor{Text: s.text, Source: DistractorSimilarRomaji})
+	}
+	for _, variant := range romajiVariants(answer) {
+		similarRomaji = append(similarRomaji, Distractor{Text: variant, Source: DistractorSimilarRomaji})
+	}
+
+	result := append(append(wrongReadType, sameMeanings...), similarRomaji...)
+	for _, o := range otherLanguage {
+		result = append(result, o.Distractor)
+	}
+	return dedupeDistractors(result, answer), nil
+}
+
+// DialectDistractors 为方言示例的方言名称构造干扰项，有相同普通话说法的方言可能同样正确，不作为干扰项
+func (g *DistractorGenerator) DialectDistractors(example DialectExample) ([]Distractor, error) {
+	examples, err := g.repo.ListDialectExamples()
+	if err != nil {
+		return nil, err
+	}
+
+	ambiguous := map[string]bool{example.DialectType: true}
+	for _, e := range examples {
+		if e.Standard == example.Standard {
+			ambiguous[e.DialectType] = true
+		}
+	}
+
+	var result []Distractor
+	for _, e := range examples {
+		if !ambiguous[e.DialectType] {
+			result = append(result, Distractor{Text: DialectName(e.DialectType), Source: DistractorDialectType})
+		}
+	}
+	for _, d := range dialectNames {
+		if !ambiguous[d.Type] {
+			result = append(result, Distractor{Text: d.Name, Source: DistractorDialectType})
+		}
+	}
+	return dedupeDistractors(result, DialectName(example.DialectType)), nil
+}
+
+// HanjaDistractors 为韩语汉字词一个音节对应的汉字构造干扰项，读音相同的其他汉字最容易混淆，排在前面
+func (g *DistractorGenerator) HanjaDistractors(target Vocabulary, syllable HanjaSyllable) ([]Distractor, error) {
+	vocabs, err := g.repo.GetVocabulariesByLanguage("ko")
+	if err != nil {
+		return nil, err
+	}
+
+	var sameHangul, sameWord, other []Distractor
+	for _, v := range vocabs {
+		for _, s := range v.HanjaSyllables() {
+			switch {
+			case s.Hanja == syllable.Hanja:
+			case s.Hangul == syllable.Hangul:
+				sameHangul = append(sameHangul, Distractor{Text: s.Hanja, Source: DistractorSameHangul})
+			case v.ID == target.ID:
+				sameWord = append(sameWord, Distractor{Text: s.Hanja, Source: DistractorSameWord})
+			default:
+				other = append(other, Distractor{Text: s.Hanja, Source: DistractorOtherHanja})
+			}
+		}
+	}
+
+	result := append(append(sameHangul, sameWord...), other...)
+	return dedupeDistractors(result, syllable.Hanja), nil
+}
+
+// MorphemeDistractors 为词素构造干扰项：同一个词的其他词素最容易混淆，其次是其他词中对应不同汉字的词素；
+// 对应同一个汉字的词素（如 phone 和 logue 都对应 话）同样正确，不作为干扰项
+func (g *DistractorGenerator) MorphemeDistractors(target Vocabulary, morpheme Morpheme) ([]Distractor, error) {
+	vocabs, err := g.repo.GetVocabulariesByLanguage(target.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	var sameWord, other []Distractor
+	for _, v := range vocabs {
+		for _, m := range v.SplitMorphemes() {
+			switch {
+			case m.Chinese != "" && m.Chinese == morpheme.Chinese:
+			case v.ID == target.ID:
+				sameWord = append(sameWord, Distractor{Text: m.Text, Source: DistractorSameWord})
+			case m.Chinese != "":
+				other = append(other, Distractor{Text: m.Text, Source: DistractorOtherMorpheme})
+			}
+		}
+	}
+	return dedupeDistractors(append(sameWord, other...), morpheme.Text), nil
+}
+
+// ConceptDistractors 为词汇对应的中文词构造干扰项，同一语言中包含相同字根的其他中文词排在前面
+func (g *DistractorGenerator) ConceptDistractors(target Vocabulary) ([]Distractor, error) {
+	vocabs, err := g.repo.GetVocabulariesByLanguage(target.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	var sameRoot, other []Distractor
+	for _, v := range vocabs {
+		if v.Chinese == "" || v.Chinese == target.Chinese {
+			continue
+		}
+		if sharesRoot(v, target) {
+			sameRoot = append(sameRoot, Distractor{Text: v.Chinese, Source: DistractorSameRoot})
+		} else {
+			other = append(other, Distractor{Text: v.Chinese, Source: DistractorOtherConcept})
+		}
+	}
+	return dedupeDistractors(append(sameRoot, other...), target.Chinese), nil
+}
+
+// HanVietDistractors 为越南语汉越词一个音节的读音构造干扰项：只差声调的写法最容易混淆，排在最前面；
+// 其他词中对应同一个汉字的音节同样正确，不作为干扰项
+func (g *DistractorGenerator) HanVietDistractors(target Vocabulary, syllable HanVietSyllable) ([]Distractor, error) {
+	vocabs, err := g.repo.GetVocabulariesByLanguage("vi")
+	if err != nil {
+		return nil, err
+	}
+
+	var tones, sameWord, other []Distractor
+	for _, variant := range vietnameseToneVariants(syllable.Syllable) {
+		tones = append(tones, Distractor{Text: variant, Source: DistractorToneMark})
+	}
+	for _, v := range vocabs {
+		for _, s := range v.HanVietSyllables() {
+			switch {
+			case s.Chinese == syllable.Chinese:
+			case v.ID == target.ID:
+				sameWord = append(sameWord, Distractor{Text: s.Syllable, Source: DistractorSameWord})
+			default:
+				other = append(other, Distractor{Text: s.Syllable, Source: DistractorOtherSyllable})
+			}
+		}
+	}
+
+	result := append(append(tones, sameWord...), other...)
+	return dedupeDistractors(result, syllable.Syllable), nil
+}
+
+// VariantDistractors 为字根在某个地区的写法构造干扰项：同一字根在其他地区的写法最容易混淆，排在前面；
+// 其次是其他字根在同一地区与简体不同的写法，难度相近的排在前面。题目中给出的简体字不作为干扰项
+func (g *DistractorGenerator) VariantDistractors(root CharacterRoot, region string) ([]Distractor, error) {
+	roots, err := g.repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+
+	var sameRoot []Distractor
+	for _, form := range root.Forms() {
+		if form != root.Root {
+			sameRoot = append(sameRoot, Distractor{Text: form, Source: DistractorOtherVariant})
+		}
+	}
+
+	var others []CharacterRoot
+	for _, other := range roots {
+		if other.ID != root.ID && other.Form(region) != other.Root && !root.HasForm(other.Form(region)) {
+			others = append(others, other)
+		}
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		return abs(others[i].Difficulty-root.Difficulty) < abs(others[j].Difficulty-root.Difficulty)
+	})
+	regionForms := make([]Distractor, len(others))
+	for i, other := range others {
+		regionForms[i] = Distractor{Text: other.Form(region), Source: DistractorRegionVariant}
+	}
+
+	return dedupeDistractors(append(sameRoot, regionForms...), root.Form(region)), nil
+}
+
+// Distractors 创建使用当前内容仓库的干扰项生成器
+func (c *LevelContext) Distractors() *DistractorGenerator {
+	return NewDistractorGenerator(c.Repo)
+}
+
+// similarReading 拼写相近的读音及其编辑距离
+type similarReading struct {
+	text     string
+	distance int
+}
+
+// otherLanguageReading 另一种语言中同字根词汇的读音，cognate 表示意思也相同
+type otherLanguageReading struct {
+	Distractor
+	cognate bool
+}
+
+// romanized 词汇的罗马字读音，统一为不带连字符和空格的小写形式，使各来源的选项格式一致
+func romanized(v Vocabulary) string {
+	reading := v.Romaji
+	if reading == "" {
+		reading = v.Pronunciation
+	}
+	reading = normalizeRomaji(reading)
+	for _, r := range reading {
+		if r < 'a' || r > 'z' {
+			return "" // 假名、谚文等非拉丁字母读音无法与罗马字选项混排
+		}
+	}
+	return reading
+}
+
+// normalizeRomaji 将罗马字转为小写并去掉连字符、空格和撇号
+func normalizeRomaji(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ', '\'':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// sharesRoot 判断两个词汇是否有共同的组成字根
+func sharesRoot(a, b Vocabulary) bool {
+	for _, id := range a.RootIDs() {
+		if b.HasRoot(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameMeaning 判断两个释义是否有相同的义项，义项以 / 分隔
+func sameMeaning(a, b string) bool {
+	senses := make(map[string]bool)
+	for _, s := range strings.Split(a, "/") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			senses[s] = true
+		}
+	}
+	for _, s := range strings.Split(b, "/") {
+		if senses[strings.ToLower(strings.TrimSpace(s))] {
+			return true
+		}
+	}
+	return false
+}
+
+// romajiVariants 长音和促音的常见误读，如 gakkou → gakou、denchi → denchii
+func romajiVariants(romaji string) []string {
+	var variants []string
+
+	// 长音：去掉或补上一个长音
+	switch {
+	case strings.Contains(romaji, "uu"):
+		variants = append(variants, strings.Replace(romaji, "uu", "u", 1))
+	case strings.Contains(romaji, "ou"):
+		variants = append(variants, strings.Replace(romaji, "ou", "o", 1))
+	case romaji != "" && strings.ContainsRune("aiueo", rune(romaji[len(romaji)-1])):
+		variants = append(variants, romaji+romaji[len(romaji)-1:])
+	}
+
+	// 促音：去掉或补上一个双写辅音
+	for i := 1; i < len(romaji); i++ {
+		c := romaji[i]
+		if !strings.ContainsRune("kstp", rune(c)) {
+			continue
+		}
+		if romaji[i-1] == c {
+			variants = append(variants, romaji[:i]+romaji[i+1:])
+			break
+		}
+		if strings.ContainsRune("aiueo", rune(romaji[i-1])) && i+1 < len(romaji) {
+			variants = append(variants, romaji[:i]+string(c)+romaji[i:])
+			break
+		}
+	}
+
+	return variants
+}
+
+// editDistance 两个字符串的编辑距离
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j], curr[j-1])+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// dedupeDistractors 去掉重复的干扰项和与正确答案相同的干扰项，保留先出现的
+func dedupeDistractors(distractors []Distractor, answer string) []Distractor {
+	seen := map[string]bool{normalizeAnswer(answer): true}
+	result := make([]Distractor, 0, len(distractors))
+	for _, d := range distractors {
+		key := normalizeAnswer(d.Text)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, d)
+	}
+	return result
+}
+
+// shuffleOptions 打乱每道选择题的选项顺序，顺序只由关卡ID和问题ID决定，同一关卡重复生成时保持一致
+func shuffleOptions(level *Level) {
+	for i := range level.Questions {
+		q := &level.Questions[i]
+		if len(q.Options) < 2 {
+			continue
+		}
+
+		h := fnv.New64a()
+		h.Write([]byte(level.ID + "/" + q.ID))
+		r := rand.New(rand.NewSource(int64(h.Sum64())))
+		r.Shuffle(len(q.Options), func(a, b int) { q.Options[a], q.Options[b] = q.Options[b], q.Options[a] })
+	}
+}
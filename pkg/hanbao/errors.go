@@ -0,0 +1,173 @@
+package hanbao
+
+import (
+	"errors"
+	"strings"
+)
+
+// 错误码，客户端根据错误码而不是错误消息进行分支处理
+const (
+	CodeInvalidRequest          = "INVALID_REQUEST"
+	CodeNoWords                 = "NO_WORDS"
+	CodeTooManyWords            = "TOO_MANY_WORDS"
+	CodeInputTooLarge           = "INPUT_TOO_LARGE"
+	CodeContentNotFound         = "CONTENT_NOT_FOUND"
+	CodeRootNotFound            = "ROOT_NOT_FOUND"
+	CodeNoUnlockedRoots         = "NO_UNLOCKED_ROOTS"
+	CodeInsufficientVocabulary  = "INSUFFICIENT_VOCABULARY"
+	CodeInvalidLevelID          = "INVALID_LEVEL_ID"
+	CodeLevelNotIssued          = "LEVEL_NOT_ISSUED"
+	CodeUnsupportedLevelType    = "UNSUPPORTED_LEVEL_TYPE"
+	CodeLevelNotFound           = "LEVEL_NOT_FOUND"
+	CodeQuestionNotFound        = "QUESTION_NOT_FOUND"
+	CodeAnswerAlreadySubmitted  = "ANSWER_ALREADY_SUBMITTED"
+	CodeAnswerTokenRequired     = "ANSWER_TOKEN_REQUIRED"
+	CodeAnswerTokenDisabled     = "ANSWER_TOKEN_DISABLED"
+	CodeInvalidAnswerToken      = "INVALID_ANSWER_TOKEN"
+	CodeAnswerTokenExpired      = "ANSWER_TOKEN_EXPIRED"
+	CodeSessionNotFound         = "SESSION_NOT_FOUND"
+	CodeVocabularyNotFound      = "VOCABULARY_NOT_FOUND"
+	CodeReviewCardNotFound      = "REVIEW_CARD_NOT_FOUND"
+	CodeInvalidReviewGrade      = "INVALID_REVIEW_GRADE"
+	CodeUnsupportedRomanization = "UNSUPPORTED_ROMANIZATION"
+	CodeInternal                = "INTERNAL_ERROR"
+)
+
+var (
+	// ErrNoWords 没有输入词语
+	ErrNoWords = NewError(CodeNoWords, "至少需要输入一个词语")
+	// ErrTooManyWords 输入的词语超过上限
+	ErrTooManyWords = NewError(CodeTooManyWords, "输入的词语数量超过上限")
+	// ErrInputTooLarge 批量分析的文本或文件超过大小上限
+	ErrInputTooLarge = NewError(CodeInputTooLarge, "输入的内容超过大小上限")
+	// ErrContentNotFound 内容不存在
+	ErrContentNotFound = NewError(CodeContentNotFound, "内容不存在")
+	// ErrRootNotFound 字根不存在
+	ErrRootNotFound = NewError(CodeRootNotFound, "字根不存在")
+	// ErrNoUnlockedRoots 会话中还没有已解锁的字根
+	ErrNoUnlockedRoots = NewError(CodeNoUnlockedRoots, "没有已解锁的字根")
+	// ErrInsufficientVocabulary 字根没有足够的词汇或方言数据生成关卡
+	ErrInsufficientVocabulary = NewError(CodeInsufficientVocabulary, "字根没有足够的词汇数据")
+	// ErrInvalidLevelID 关卡ID格式错误
+	ErrInvalidLevelID = NewError(CodeInvalidLevelID, "无效的关卡ID格式")
+	// ErrLevelNotIssued 关卡ID中的种子不是服务端签发的
+	ErrLevelNotIssued = NewError(CodeLevelNotIssued, "关卡ID不是由服务器生成的")
+	// ErrUnsupportedLevelType 不支持的关卡类型
+	ErrUnsupportedLevelType = NewError(CodeUnsupportedLevelType, "不支持的关卡类型")
+	// ErrLevelNotFound 关卡不存在或已过期
+	ErrLevelNotFound = NewError(CodeLevelNotFound, "关卡不存在或已过期")
+	// ErrQuestionNotFound 关卡中不存在该问题
+	ErrQuestionNotFound = NewError(CodeQuestionNotFound, "问题不存在")
+	// ErrAnswerAlreadySubmitted 该问题已经提交过答案
+	ErrAnswerAlreadySubmitted = NewError(CodeAnswerAlreadySubmitted, "该问题已提交过答案")
+	// ErrAnswerTokenRequired 无状态模式下提交答案必须携带答案令牌
+	ErrAnswerTokenRequired = NewError(CodeAnswerTokenRequired, "缺少答案令牌")
+	// ErrAnswerTokenDisabled 服务未启用答案令牌模式
+	ErrAnswerTokenDisabled = NewError(CodeAnswerTokenDisabled, "未启用答案令牌模式")
+	// ErrInvalidAnswerToken 答案令牌无法解密或签名不匹配
+	ErrInvalidAnswerToken = NewError(CodeInvalidAnswerToken, "无效的答案令牌")
+	// ErrAnswerTokenExpired 答案令牌已过期
+	ErrAnswerTokenExpired = NewError(CodeAnswerTokenExpired, "答案令牌已过期")
+	// ErrSessionNotFound 会话不存在或已过期
+	ErrSessionNotFound = NewError(CodeSessionNotFound, "会话不存在或已过期")
+	// ErrVocabularyNotFound 词汇不存在
+	ErrVocabularyNotFound = NewError(CodeVocabularyNotFound, "词汇不存在")
+	// ErrReviewCardNotFound 复习卡片不存在
+	ErrReviewCardNotFound = NewError(CodeReviewCardNotFound, "复习卡片不存在")
+	// ErrInvalidReviewGrade 复习评分不在 1-4 之间
+	ErrInvalidReviewGrade = NewError(CodeInvalidReviewGrade, "复习评分必须在1到4之间")
+	// ErrUnsupportedRomanization 不支持的语言或罗马字转写方案
+	ErrUnsupportedRomanization = NewError(CodeUnsupportedRomanization, "不支持的语言或罗马字转写方案")
+)
+
+// Error 带错误码的业务错误
+type Error struct {
+	Code    string         // 稳定的错误码
+	Message string         // 默认（中文）错误消息
+	Details map[string]any // 附加信息，如超出的上限、不存在的ID
+}
+
+// NewError 创建业务错误
+func NewError(code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is 按错误码判断是否为同一种错误，使带附加信息的副本也能匹配哨兵错误
+func (e *Error) Is(target error) bool {
+	var t *Error
+	return errors.As(target, &t) && t.Code == e.Code
+}
+
+// WithDetails 返回附带信息的错误副本，不修改原错误
+func (e *Error) WithDetails(details map[string]any) *Error {
+	clone := *e
+	clone.Details = make(map[string]any, len(e.Details)+len(details))
+	for k, v := range e.Details {
+		clone.Details[k] = v
+	}
+	for k, v := range details {
+		clone.Details[k] = v
+	}
+	return &clone
+}
+
+// LocalizedMessage 返回指定语言的错误消息，没有对应翻译时返回默认消息
+func (e *Error) LocalizedMessage(lang string) string {
+	if messages, ok := errorMessages[normalizeLang(lang)]; ok {
+		if message, ok := messages[e.Code]; ok {
+			return message
+		}
+	}
+	return e.Message
+}
+
+// errorMessages 错误消息翻译，按语言和错误码索引，默认消息为中文
+var errorMessages = map[string]map[string]string{
+	"en": {
+		CodeInvalidRequest:          "invalid request",
+		CodeNoWords:                 "at least one word is required",
+		CodeTooManyWords:            "too many words",
+		CodeInputTooLarge:           "input is too large",
+		CodeContentNotFound:         "content not found",
+		CodeRootNotFound:            "character root not found",
+		CodeNoUnlockedRoots:         "no character roots unlocked yet",
+		CodeInsufficientVocabulary:  "not enough vocabulary for this character root",
+		CodeInvalidLevelID:          "invalid level ID",
+		CodeLevelNotIssued:          "level ID was not issued by this server",
+		CodeUnsupportedLevelType:    "unsupported level type",
+		CodeLevelNotFound:           "level not found or expired",
+		CodeQuestionNotFound:        "question not found",
+		CodeAnswerAlreadySubmitted:  "answer already submitted for this question",
+		CodeAnswerTokenRequired:     "answer token is required",
+		CodeAnswerTokenDisabled:     "answer token mode is not enabled",
+		CodeInvalidAnswerToken:      "invalid answer token",
+		CodeAnswerTokenExpired:      "answer token expired",
+		CodeSessionNotFound:         "session not found or expired",
+		CodeVocabularyNotFound:      "vocabulary not found",
+		CodeReviewCardNotFound:      "review card not found",
+		CodeInvalidReviewGrade:      "review grade must be between 1 and 4",
+		CodeUnsupportedRomanization: "unsupported language or romanization scheme",
+		CodeInternal:                "internal server error",
+	},
+	"zh": {
+		CodeInvalidRequest: "请求参数错误",
+		CodeInternal:       "服务器内部错误",
+	},
+}
+
+// normalizeLang 将 en-US、zh_CN 等语言标签归一为主语言代码
+func normalizeLang(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
@@ -0,0 +1,236 @@
+package hanbao
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FrequencyList 某种语言的词频表，按文件中的顺序（通常从高频到低频）保存词和出现次数
+type FrequencyList struct {
+	Language string
+	words    []string
+	counts   []float64
+}
+
+// LoadFrequencyList 加载词频表文件。每行一个词，后面可以跟出现次数（空白或制表符分隔），
+// 如 "電話 12345"；没有出现次数时按行号以齐普夫定律估算（第n个词的频率为 1/n）；
+// 空行和 # 开头的行忽略，同一个词（不区分大小写）出现多次时合并
+func LoadFrequencyList(language, path string) (*FrequencyList, error) {
+	if LanguageName(language) == language {
+		return nil, fmt.Errorf("不支持的词频表语言: %s", language)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseFrequencyList(language, path, f)
+}
+
+// parseFrequencyList 解析词频表，错误定位到文件和行
+func parseFrequencyList(language, file string, r io.Reader) (*FrequencyList, error) {
+	list := &FrequencyList{Language: language}
+	index := make(map[string]int)
+	decided, withCounts := false, false // 由第一个词决定整个文件是否带出现次数
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for row := 1; scanner.Scan(); row++ {
+		line := strings.TrimSpace(scanner.Text())
+		if row == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		word, count, hasCount := line, 0.0, false
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			if n, err := strconv.ParseFloat(fields[len(fields)-1], 64); err == nil {
+				word, count, hasCount = strings.Join(fields[:len(fields)-1], " "), n, true
+			}
+		}
+		if !decided {
+			decided, withCounts = true, hasCount
+		}
+		switch {
+		case hasCount != withCounts:
+			return nil, ValidationError{File: file, Row: row, Field: "count", Message: "所有行都必须填写出现次数，或者都不填写"}
+		case count < 0:
+			return nil, ValidationError{File: file, Row: row, Field: "count", Message: fmt.Sprintf("出现次数不能为负数: %v", count)}
+		case !withCounts:
+			count = 1 / float64(len(list.words)+1)
+		}
+
+		key := segmentKey(word)
+		if i, exists := index[key]; exists {
+			if withCounts {
+				list.counts[i] += count
+			}
+			continue
+		}
+		index[key] = len(list.words)
+		list.words = append(list.words, word)
+		list.counts = append(list.counts, count)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if len(list.words) == 0 {
+		return nil, fmt.Errorf("%s: 词频表为空", file)
+	}
+	return list, nil
+}
+
+// Len 词频表的词数
+func (l *FrequencyList) Len() int {
+	return len(l.words)
+}
+
+// TextCoverage 按词频估算的已解锁字根在某种语言文本中的覆盖率
+type TextCoverage struct {
+	Language  string  `json:"language"`
+	Name      string  `json:"name"`
+	Words     int     `json:"words"`      // 词频表中已解锁的词数
+	ListWords int     `json:"list_words"` // 词频表的词数
+	Percent   float64 `json:"percent"`    // 已解锁的词在文本中出现的比例（按词频加权，0-100）
+}
+
+// CoverageEstimator 根据词频表估算字根在各语言文本中的覆盖率。
+// 与 Vocabulary.UnlockedBy 一致，词频表中的词只有所需的字根全部解锁时才算已解锁：
+// 词中的每个汉字（包括繁体、新字体）都必须对应字根，有汉字没有收录为字根的词不会解锁；
+// 韩语和英语词只能通过词汇表中收录的词对应字根，因此结果是覆盖率的下限
+type CoverageEstimator struct {
+	indexes []*frequencyIndex // 按 Languages 的顺序排列
+}
+
+// frequencyIndex 词频表的字根索引
+type frequencyIndex struct {
+	language string
+	counts   []float64
+	total    float64
+	roots    [][]int64       // 每个词解锁所需的字根，无法解锁的词为空
+	byRoot   map[int64][]int // 字根 → 需要该字根的词在词频表中的位置
+}
+
+// NewCoverageEstimator 创建覆盖率估算器，字根和词汇在创建时读取，内容更新后需要重新创建
+func NewCoverageEstimator(repo ContentRepository, lists ...*FrequencyList) (*CoverageEstimator, error) {
+	roots, err := repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+	vocabularies, err := repo.ListVocabularies()
+	if err != nil {
+		return nil, err
+	}
+	segmenter := NewSegmenter(roots, vocabularies)
+
+	byLanguage := make(map[string]*FrequencyList, len(lists))
+	for _, list := range lists {
+		byLanguage[list.Language] = list
+	}
+
+	e := &CoverageEstimator{}
+	for _, language := range Languages {
+		list, ok := byLanguage[language.Code]
+		if !ok {
+			continue
+		}
+		index := &frequencyIndex{
+			language: language.Code,
+			counts:   list.counts,
+			roots:    make([][]int64, len(list.words)),
+			byRoot:   make(map[int64][]int),
+		}
+		for i, word := range list.words {
+			index.total += list.counts[i]
+			index.roots[i] = requiredRoots(segmenter, word)
+			for _, id := range index.roots[i] {
+				index.byRoot[id] = append(index.byRoot[id], i)
+			}
+		}
+		e.indexes = append(e.indexes, index)
+	}
+	return e, nil
+}
+
+// Estimate 估算字根在各语言文本中的覆盖率，按 Languages 的顺序排列；没有加载词频表时返回 nil
+func (e *CoverageEstimator) Estimate(rootIDs []int64) []TextCoverage {
+	if e == nil || len(e.indexes) == 0 {
+		return nil
+	}
+
+	unlocked := make(map[int64]bool, len(rootIDs))
+	for _, id := range rootIDs {
+		unlocked[id] = true
+	}
+
+	coverage := make([]TextCoverage, 0, len(e.indexes))
+	for _, index := range e.indexes {
+		covered := make(map[int]bool)
+		weight := 0.0
+		for _, rootID := range rootIDs {
+			for _, i := range index.byRoot[rootID] {
+				if covered[i] || !allUnlocked(index.roots[i], unlocked) {
+					continue
+				}
+				covered[i] = true
+				weight += index.counts[i]
+			}
+		}
+
+		estimate := TextCoverage{
+			Language:  index.language,
+			Name:      LanguageName(index.language),
+			Words:     len(covered),
+			ListWords: len(index.counts),
+		}
+		if index.total > 0 {
+			estimate.Percent = weight / index.total * 100
+		}
+		coverage = append(coverage, estimate)
+	}
+	return coverage
+}
+
+// requiredRoots 词频表中的词解锁所需的字根：含汉字的部分按每个汉字对应的字根计算，有汉字没有对应字根时返回 nil；
+// 不含汉字的部分（如韩语、英语词）按词典匹配到的字根计算，没有匹配到的部分（如假名词尾、助词）不影响解锁
+func requiredRoots(segmenter *Segmenter, word string) []int64 {
+	var rootIDs []int64
+	for _, segment := range segmenter.Segment(word) {
+		hasHan := false
+		for _, r := range segment.Text {
+			if !IsHanIdeograph(r) {
+				continue
+			}
+			hasHan = true
+			id, ok := segmenter.rootsByChar[string(r)]
+			if !ok {
+				return nil
+			}
+			rootIDs = appendUniqueID(rootIDs, id)
+		}
+		if !hasHan {
+			for _, id := range segment.RootIDs {
+				rootIDs = appendUniqueID(rootIDs, id)
+			}
+		}
+	}
+	return rootIDs
+}
+
+// allUnlocked 字根是否全部已解锁
+func allUnlocked(rootIDs []int64, unlocked map[int64]bool) bool {
+	for _, id := range rootIDs {
+		if !unlocked[id] {
+			return false
+		}
+	}
+	return true
+}
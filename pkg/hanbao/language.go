@@ -0,0 +1,37 @@
+package hanbao
+
+// Language 词汇支持的语言
+type Language struct {
+	Code      string `json:"code"`      // 语言代码，与 Vocabulary.Language 一致
+	Name      string `json:"name"`      // 中文名称，如 "日语"
+	Sinoxenic bool   `json:"sinoxenic"` // 是否直接借用汉字的读音（汉字词），英语等语言只在词素意义上与汉字对应
+	Note      string `json:"note"`      // 解锁洞察中对该语言词汇的说明
+}
+
+// Languages 支持的语言，按解锁洞察和统计中展示的顺序排列
+var Languages = []Language{
+	{Code: "ja", Name: "日语", Sinoxenic: true, Note: "包括音读和训读"},
+	{Code: "ko", Name: "韩语", Sinoxenic: true, Note: "汉字词"},
+	{Code: "en", Name: "英语", Note: "按词素与汉字对应"},
+	{Code: "vi", Name: "越南语", Sinoxenic: true, Note: "汉越词"},
+}
+
+// LanguageName 语言代码的中文名称，未知语言原样返回
+func LanguageName(code string) string {
+	for _, l := range Languages {
+		if l.Code == code {
+			return l.Name
+		}
+	}
+	return code
+}
+
+// isSinoxenic 判断语言的词汇是否直接借用了汉字的读音，只有这些语言的读音可以互相作为读音题的干扰项
+func isSinoxenic(code string) bool {
+	for _, l := range Languages {
+		if l.Code == code {
+			return l.Sinoxenic
+		}
+	}
+	return false
+}
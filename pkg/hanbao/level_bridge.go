@@ -0,0 +1,149 @@
+package hanbao
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterLevelGenerator(bridgeLevel{})
+}
+
+// bridgeLevel 英语概念桥：英语词和中文词一样由表意的部件组成，如 tele + phone ↔ 电 + 话
+type bridgeLevel struct{}
+
+// Metadata 关卡类型元数据
+func (bridgeLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "concept_bridge",
+		Aliases:      []string{"bridge"},
+		Title:        "英语概念桥",
+		Icon:         "🌉",
+		Description:  "把英语词拆成词素，和中文词的每个汉字对应起来，如 tele + phone ↔ 电 + 话",
+		TimeLimit:    180,
+		Score:        120,
+		RequiredData: []string{"vocabulary:en", "morphemes"},
+	}
+}
+
+// Available 字根至少需要1个标注了词素的英语词汇
+func (bridgeLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	vocabs, err := bridgeVocabularies(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	return len(vocabs) > 0, nil
+}
+
+// Generate 生成英语概念桥关卡：先找出英语词对应的中文词，再逐个词素对应到汉字，每个词素单独计分
+func (g bridgeLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	vocabs, err := bridgeVocabularies(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(vocabs) == 0 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "en"})
+	}
+	vocab := vocabs[ctx.Rand.Intn(len(vocabs))]
+
+	conceptDistractors, err := ctx.Distractors().ConceptDistractors(vocab)
+	if err != nil {
+		return nil, err
+	}
+
+	morphemes := vocab.SplitMorphemes()
+	texts := make([]string, len(morphemes))
+	for i, m := range morphemes {
+		texts[i] = m.Text
+	}
+	breakdown := fmt.Sprintf("%s = %s", vocab.Word, strings.Join(texts, " + "))
+
+	word := vocab.Word
+	if DifficultyShowsReading(difficulty) {
+		word = fmt.Sprintf("%s（%s）", vocab.Word, vocab.Pronunciation)
+	}
+
+	questions := []Question{
+		{
+			ID:            "q1",
+			Type:          "multiple_choice",
+			Content:       fmt.Sprintf("英语 %s 对应哪个中文词？", word),
+			Options:       ctx.Options(vocab.Chinese, conceptDistractors, difficulty),
+			CorrectAnswer: vocab.Chinese,
+			Hint:          ctx.Hint(difficulty, fmt.Sprintf("把它拆开看：%s", breakdown)),
+			Explanation:   fmt.Sprintf("%s 就是中文的「%s」", vocab.Word, vocab.Chinese),
+			VocabularyIDs: []int64{vocab.ID},
+		},
+	}
+
+	for _, m := range morphemes {
+		if m.Position < 0 {
+			continue
+		}
+
+		distractors, err := ctx.Distractors().MorphemeDistractors(vocab, m)
+		if err != nil {
+			return nil, err
+		}
+
+		// 题面只给出汉字在中文词中的位置：直接写出汉字会把 q1 的答案拼出来
+		content := fmt.Sprintf("上一题的中文词里第%d个字，在英语 %s 中对应哪个部分？", m.Position+1, vocab.Word)
+		hint := fmt.Sprintf("%s 由 %s 组成，想一想哪个部分表达了这个字的意思", vocab.Word, strings.Join(texts, " + "))
+		questions = append(questions, Question{
+			ID:            fmt.Sprintf("q%d", len(questions)+1),
+			Type:          "multiple_choice",
+			Content:       content,
+			Options:       ctx.Options(m.Text, distractors, difficulty),
+			CorrectAnswer: m.Text,
+			Hint:          ctx.Hint(difficulty, hint),
+			Explanation:   morphemeExplanation(vocab, morphemes, m),
+			VocabularyIDs: []int64{vocab.ID},
+		})
+	}
+
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("从\"%s\"出发，搭一座通往英语的桥", root.Root)
+	level.Questions = questions
+	return level, nil
+}
+
+// bridgeVocabularies 字根下至少有一个词素与汉字对应的英语词汇
+func bridgeVocabularies(ctx *LevelContext, root *CharacterRoot) ([]Vocabulary, error) {
+	enVocabs, err := ctx.VocabulariesByLanguage(root.ID, "en")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Vocabulary
+	for _, vocab := range enVocabs {
+		for _, m := range vocab.SplitMorphemes() {
+			if m.Position >= 0 {
+				result = append(result, vocab)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// morphemeExplanation 当前词素与汉字的对应关系，只写出这一对，不泄露同一个词其他词素题的答案；
+// 词素在英语词中的次序与汉字在中文词中的位置不同时特别说明
+func morphemeExplanation(vocab Vocabulary, morphemes []Morpheme, m Morpheme) string {
+	// index 是词素在英语词中的次序，order 只数对应了汉字的词素，用来和字序比较
+	index, order := 0, 0
+	for i, other := range morphemes {
+		if other == m {
+			index = i
+			break
+		}
+		if other.Position >= 0 {
+			order++
+		}
+	}
+
+	explanation := fmt.Sprintf("%s 对应「%s」，是中文词的第%d个字", m.Text, m.Chinese, m.Position+1)
+	if order != m.Position {
+		explanation += fmt.Sprintf("。注意 %s 在英语 %s 中是第%d个词素，英语的词素顺序和中文的字序不一样", m.Text, vocab.Word, index+1)
+	}
+	return explanation
+}
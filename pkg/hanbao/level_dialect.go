@@ -0,0 +1,67 @@
+package hanbao
+
+import "fmt"
+
+func init() {
+	RegisterLevelGenerator(dialectLevel{})
+}
+
+// dialectLevel 方言连接彩蛋：对比方言与日韩语中保留的古音
+type dialectLevel struct{}
+
+// Metadata 关卡类型元数据
+func (dialectLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "dialect",
+		Title:        "方言连接彩蛋",
+		Icon:         "🗺️",
+		Description:  "辨认保留古汉语说法的方言",
+		TimeLimit:    120, // 2分钟
+		Score:        80,
+		RequiredData: []string{"dialect"},
+	}
+}
+
+// Available 字根至少需要1个方言示例
+func (dialectLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	examples, err := ctx.Repo.GetDialectExamplesByRoot(root.ID)
+	if err != nil {
+		return false, err
+	}
+	return len(examples) > 0, nil
+}
+
+// Generate 生成方言连接彩蛋关卡
+func (g dialectLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	// 查找相关的方言例子
+	examples, err := ctx.Repo.GetDialectExamplesByRoot(root.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(examples) == 0 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "dialect"})
+	}
+	dialectExample := examples[ctx.Rand.Intn(len(examples))]
+
+	distractors, err := ctx.Distractors().DialectDistractors(dialectExample)
+	if err != nil {
+		return nil, err
+	}
+
+	correct := DialectName(dialectExample.DialectType)
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("探索\"%s\"的方言奥秘", root.Root)
+	level.Questions = []Question{
+		{
+			ID:            "q1",
+			Type:          "multiple_choice",
+			Content:       fmt.Sprintf("普通话的\"%s\"，在哪种方言里说成\"%s\"？", dialectExample.Standard, dialectExample.Dialect),
+			Options:       ctx.Options(correct, distractors, difficulty),
+			CorrectAnswer: correct,
+			Hint:          ctx.Hint(difficulty, "汉字读音是一部活的迁徙史"),
+			Explanation:   dialectExample.Description,
+		},
+	}
+
+	return level, nil
+}
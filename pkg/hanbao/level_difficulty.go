@@ -0,0 +1,73 @@
+package hanbao
+
+// 关卡难度范围
+const (
+	MinLevelDifficulty = 1
+	MaxLevelDifficulty = 5
+)
+
+// minTimeLimit 难度再高也至少保留的答题时间（秒）
+const minTimeLimit = 30
+
+// normalizeDifficulty 将难度限制在 MinLevelDifficulty 到 MaxLevelDifficulty 之间
+func normalizeDifficulty(difficulty int) int {
+	return min(max(difficulty, MinLevelDifficulty), MaxLevelDifficulty)
+}
+
+// DifficultyTimeLimit 难度每提高一级，时间限制缩短15%
+func DifficultyTimeLimit(base, difficulty int) int {
+	limit := base * (100 - 15*(normalizeDifficulty(difficulty)-MinLevelDifficulty)) / 100
+	return max(limit, min(base, minTimeLimit))
+}
+
+// DifficultyOptionCount 选择题的选项数量，难度1为3个选项，每提高一级多一个干扰项
+func DifficultyOptionCount(difficulty int) int {
+	return normalizeDifficulty(difficulty) + 2
+}
+
+// DifficultyShowsHint 难度3及以上不提供答题提示
+func DifficultyShowsHint(difficulty int) bool {
+	return difficulty < 3
+}
+
+// DifficultyShowsReading 难度4及以上不标注词汇读音
+func DifficultyShowsReading(difficulty int) bool {
+	return difficulty < 4
+}
+
+// Hint 按难度返回提示，高难度时为空
+func (c *LevelContext) Hint(difficulty int, hint string) string {
+	if !DifficultyShowsHint(difficulty) {
+		return ""
+	}
+	return hint
+}
+
+// Options 按难度选取干扰项，轮流从各个来源中取，使选项包含不同类型的迷惑；
+// 正确答案排在第一个，选项顺序由 LevelService 按关卡ID统一打乱
+func (c *LevelContext) Options(correct string, distractors []Distractor, difficulty int) []string {
+	var sources []string
+	bySource := make(map[string][]string)
+	for _, d := range distractors {
+		if _, ok := bySource[d.Source]; !ok {
+			sources = append(sources, d.Source)
+		}
+		bySource[d.Source] = append(bySource[d.Source], d.Text)
+	}
+
+	options := []string{correct}
+	want := DifficultyOptionCount(difficulty)
+	for round := 0; len(options) < want; round++ {
+		added := false
+		for _, source := range sources {
+			if texts := bySource[source]; round < len(texts) && len(options) < want {
+				options = append(options, texts[round])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return options
+}
@@ -0,0 +1,151 @@
+package hanbao
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// LevelGenerator 关卡生成器，每种关卡类型实现一个，通过 RegisterLevelGenerator 注册
+type LevelGenerator interface {
+	// Metadata 关卡类型的元数据
+	Metadata() LevelTypeMetadata
+	// Available 判断字根是否有生成该关卡所需的数据
+	Available(ctx *LevelContext, root *CharacterRoot) (bool, error)
+	// Generate 为字根生成关卡，difficulty 已限制在有效范围内，应影响干扰项数量和提示等题目构造；
+	// 问题分值、答案令牌和保存由 LevelService 统一处理
+	Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error)
+}
+
+// LevelTypeMetadata 关卡类型元数据
+type LevelTypeMetadata struct {
+	Type         string   `json:"type"`          // 类型名称，如 pronunciation
+	Aliases      []string `json:"aliases"`       // 别名，第一个别名同时作为关卡ID前缀
+	Title        string   `json:"title"`         // 关卡标题
+	Icon         string   `json:"icon"`          // 图标
+	Description  string   `json:"description"`   // 玩法说明
+	TimeLimit    int      `json:"time_limit"`    // 难度1的时间限制（秒）
+	Score        int      `json:"score"`         // 通关奖励分数
+	RequiredData []string `json:"required_data"` // 需要的内容数据，如 vocabulary:ja、dialect
+}
+
+// idPrefix 关卡ID前缀
+func (m LevelTypeMetadata) idPrefix() string {
+	if len(m.Aliases) > 0 {
+		return m.Aliases[0]
+	}
+	return m.Type
+}
+
+// LevelContext 生成一个关卡时使用的内容仓库和随机数，每次生成都创建新的上下文，
+// Rand 由关卡种子初始化，生成器只使用 Rand 做随机选择即可保证同一关卡可以重新生成
+type LevelContext struct {
+	Repo ContentRepository
+	Rand *rand.Rand
+	Seed uint64
+}
+
+// NewLevel 按元数据创建关卡骨架，时间限制随难度缩短，生成器只需要填写描述和问题
+func (c *LevelContext) NewLevel(meta LevelTypeMetadata, root *CharacterRoot, difficulty int) *Level {
+	return &Level{
+		ID:         LevelKey{Type: meta.idPrefix(), RootID: root.ID, Difficulty: difficulty, Seed: c.Seed}.String(),
+		Type:       meta.Type,
+		Title:      meta.Title + " " + meta.Icon,
+		RootID:     root.ID,
+		Difficulty: difficulty,
+		TimeLimit:  DifficultyTimeLimit(meta.TimeLimit, difficulty),
+		Reward: Reward{
+			Roots: []int64{root.ID},
+			Score: meta.Score,
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// VocabulariesByLanguage 获取字根在指定语言中的词汇
+func (c *LevelContext) VocabulariesByLanguage(rootID int64, language string) ([]Vocabulary, error) {
+	vocabs, err := c.Repo.GetVocabulariesByRoot(rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Vocabulary
+	for _, vocab := range vocabs {
+		if vocab.Language == language {
+			result = append(result, vocab)
+		}
+	}
+	return result, nil
+}
+
+// LevelRegistry 关卡类型注册表，按类型名称或别名查找生成器
+type LevelRegistry struct {
+	mu         sync.RWMutex
+	generators map[string]LevelGenerator
+	aliases    map[string]string
+	order      []string
+}
+
+// NewLevelRegistry 创建空的关卡类型注册表
+func NewLevelRegistry() *LevelRegistry {
+	return &LevelRegistry{
+		generators: make(map[string]LevelGenerator),
+		aliases:    make(map[string]string),
+	}
+}
+
+// DefaultLevelRegistry 内置关卡类型的注册表
+var DefaultLevelRegistry = NewLevelRegistry()
+
+// RegisterLevelGenerator 向默认注册表注册关卡生成器，名称冲突时 panic，通常在 init 中调用
+func RegisterLevelGenerator(gen LevelGenerator) {
+	if err := DefaultLevelRegistry.Register(gen); err != nil {
+		panic(err)
+	}
+}
+
+// Register 注册关卡生成器，类型名称和别名都不能与已注册的重复
+func (r *LevelRegistry) Register(gen LevelGenerator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	meta := gen.Metadata()
+	names := append([]string{meta.Type}, meta.Aliases...)
+	for _, name := range names {
+		if _, ok := r.aliases[name]; ok {
+			return fmt.Errorf("关卡类型名称重复: %s", name)
+		}
+	}
+
+	for _, name := range names {
+		r.aliases[name] = meta.Type
+	}
+	r.generators[meta.Type] = gen
+	r.order = append(r.order, meta.Type)
+	return nil
+}
+
+// Lookup 按类型名称或别名查找生成器
+func (r *LevelRegistry) Lookup(name string) (LevelGenerator, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	levelType, ok := r.aliases[name]
+	if !ok {
+		return nil, false
+	}
+	return r.generators[levelType], true
+}
+
+// List 按注册顺序列出所有生成器
+func (r *LevelRegistry) List() []LevelGenerator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]LevelGenerator, len(r.order))
+	for i, levelType := range r.order {
+		result[i] = r.generators[levelType]
+	}
+	return result
+}
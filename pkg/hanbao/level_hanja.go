@@ -0,0 +1,125 @@
+package hanbao
+
+import "fmt"
+
+func init() {
+	RegisterLevelGenerator(hanjaLevel{})
+}
+
+// hanjaLevel 谚文汉字解码：把韩语汉字词的每个音节还原成对应的汉字，每个音节单独计分
+type hanjaLevel struct{}
+
+// Metadata 关卡类型元数据
+func (hanjaLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "hanja",
+		Aliases:      []string{"hangul"},
+		Title:        "谚文汉字解码",
+		Icon:         "🔤",
+		Description:  "把韩语汉字词的每个谚文音节还原成汉字，如 전→電、화→話",
+		TimeLimit:    180,
+		Score:        120,
+		RequiredData: []string{"vocabulary:ko", "hanja"},
+	}
+}
+
+// Available 字根至少需要1个标注了汉字的韩语词汇
+func (hanjaLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	vocabs, err := hanjaVocabularies(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	return len(vocabs) > 0, nil
+}
+
+// Generate 生成谚文汉字解码关卡，每个音节一道题
+func (g hanjaLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	vocabs, err := hanjaVocabularies(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(vocabs) == 0 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "ko"})
+	}
+	vocab := vocabs[ctx.Rand.Intn(len(vocabs))]
+
+	word := vocab.Word
+	if DifficultyShowsReading(difficulty) {
+		word = fmt.Sprintf("%s（%s，意思是 %s）", vocab.Word, vocab.Pronunciation, vocab.Meaning)
+	}
+
+	syllables := vocab.HanjaSyllables()
+	questions := make([]Question, 0, len(syllables))
+	for _, s := range syllables {
+		distractors, err := ctx.Distractors().HanjaDistractors(vocab, s)
+		if err != nil {
+			return nil, err
+		}
+
+		explanation, hint, rules, err := hanjaSyllableLink(ctx, vocab, s)
+		if err != nil {
+			return nil, err
+		}
+
+		questions = append(questions, Question{
+			ID:            fmt.Sprintf("q%d", s.Position+1),
+			Type:          "multiple_choice",
+			Content:       fmt.Sprintf("韩语词「%s」的第%d个音节「%s」对应哪个汉字？", word, s.Position+1, s.Hangul),
+			Options:       ctx.Options(s.Hanja, distractors, difficulty),
+			CorrectAnswer: s.Hanja,
+			Hint:          ctx.Hint(difficulty, hint),
+			Explanation:   explanation,
+			VocabularyIDs: []int64{vocab.ID},
+			SoundRules:    rules,
+			RuleLanguage:  "ko",
+		})
+	}
+
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("把含有\"%s\"的韩语词逐个音节还原成汉字", root.Root)
+	level.Questions = questions
+	return level, nil
+}
+
+// hanjaVocabularies 字根下汉字与音节一一对应的韩语词汇
+func hanjaVocabularies(ctx *LevelContext, root *CharacterRoot) ([]Vocabulary, error) {
+	koVocabs, err := ctx.VocabulariesByLanguage(root.ID, "ko")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Vocabulary
+	for _, vocab := range koVocabs {
+		if len(vocab.HanjaSyllables()) > 0 {
+			result = append(result, vocab)
+		}
+	}
+	return result, nil
+}
+
+// hanjaSyllableLink 音节的解释和提示，收录了组成字根时与中文字形、读音对照，并给出读音符合的对应规律；
+// 解释只涉及当前音节，提示不写出要选的汉字和它的中文读音，避免泄露本题和同一个词其他音节的答案
+func hanjaSyllableLink(ctx *LevelContext, vocab Vocabulary, s HanjaSyllable) (explanation, hint string, rules []string, err error) {
+	explanation = fmt.Sprintf("「%s」的第%d个音节 %s 写成汉字是 %s", vocab.Word, s.Position+1, s.Hangul, s.Hanja)
+	hint = fmt.Sprintf("想一想\"%s\"（%s）这个词的意思，哪个汉字能组成它", vocab.Word, vocab.Meaning)
+	if s.RootID == 0 {
+		return explanation, hint, nil, nil
+	}
+
+	root, err := ctx.Repo.GetRootByID(s.RootID)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if s.Reading != "" {
+		if score, ok := ScoreReading(root, "ko", s.Reading); ok && score.Score == 1 {
+			rules = soundRuleIDs(score.Rules)
+		}
+	}
+	if root.Root != s.Hanja {
+		explanation += fmt.Sprintf("；%s 就是中文的\"%s\"（%s）", s.Hanja, root.Root, root.Pinyin)
+	} else {
+		explanation += fmt.Sprintf("；%s 读 %s，中文读 %s", s.Hanja, s.Hangul, root.Pinyin)
+	}
+	hint = fmt.Sprintf("%s 是汉字的古音，中文里有一个读音相近的字，想一想它在\"%s\"（%s）中表示什么", s.Hangul, vocab.Word, vocab.Meaning)
+	return explanation, hint, rules, nil
+}
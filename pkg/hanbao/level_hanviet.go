@@ -0,0 +1,142 @@
+package hanbao
+
+import "fmt"
+
+func init() {
+	RegisterLevelGenerator(hanVietLevel{})
+}
+
+// hanVietLevel 汉越词解码：为中文词的每个汉字选出越南语的汉越音节，声调不同的写法是主要干扰项，每个音节单独计分
+type hanVietLevel struct{}
+
+// Metadata 关卡类型元数据
+func (hanVietLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "han_viet",
+		Aliases:      []string{"viet"},
+		Title:        "汉越词解码",
+		Icon:         "🇻🇳",
+		Description:  "越南语的汉越词保留了汉字的古音，为每个汉字选出带正确声调的越南语音节，如 电→điện、话→thoại",
+		TimeLimit:    180,
+		Score:        120,
+		RequiredData: []string{"vocabulary:vi", "chinese"},
+	}
+}
+
+// Available 字根至少需要1个音节与汉字对齐的越南语词汇
+func (hanVietLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	vocabs, err := hanVietVocabularies(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	return len(vocabs) > 0, nil
+}
+
+// Generate 生成汉越词解码关卡，每个音节一道题
+func (g hanVietLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	vocabs, err := hanVietVocabularies(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(vocabs) == 0 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "vi"})
+	}
+	vocab := vocabs[ctx.Rand.Intn(len(vocabs))]
+
+	word := fmt.Sprintf("「%s」", vocab.Chinese)
+	if DifficultyShowsReading(difficulty) {
+		word = fmt.Sprintf("「%s」（%s）", vocab.Chinese, vocab.Meaning)
+	}
+
+	korean, err := hanVietKoreanCognate(ctx, vocab)
+	if err != nil {
+		return nil, err
+	}
+
+	syllables := vocab.HanVietSyllables()
+	questions := make([]Question, 0, len(syllables))
+	for _, s := range syllables {
+		distractors, err := ctx.Distractors().HanVietDistractors(vocab, s)
+		if err != nil {
+			return nil, err
+		}
+
+		hint, err := hanVietHint(ctx, s, korean)
+		if err != nil {
+			return nil, err
+		}
+
+		questions = append(questions, Question{
+			ID:            fmt.Sprintf("q%d", s.Position+1),
+			Type:          "multiple_choice",
+			Content:       fmt.Sprintf("中文词%s在越南语里是汉越词，其中「%s」的汉越音是？", word, s.Chinese),
+			Options:       ctx.Options(s.Syllable, distractors, difficulty),
+			CorrectAnswer: s.Syllable,
+			Hint:          ctx.Hint(difficulty, hint),
+			Explanation:   hanVietExplanation(vocab, s),
+			VocabularyIDs: []int64{vocab.ID},
+		})
+	}
+
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("含有\"%s\"的越南语汉越词，逐个汉字还原读音", root.Root)
+	level.Questions = questions
+	return level, nil
+}
+
+// hanVietExplanation 音节的解释，只写出当前音节的读音，不泄露同一个词其他音节的答案
+func hanVietExplanation(vocab Vocabulary, s HanVietSyllable) string {
+	return fmt.Sprintf("「%s」的汉越音是 %s，是「%s」的第%d个音节。越南语有六个声调，声调符号写错就是另一个音节",
+		s.Chinese, s.Syllable, vocab.Chinese, s.Position+1)
+}
+
+// hanVietVocabularies 字根下音节与汉字一一对应的越南语词汇
+func hanVietVocabularies(ctx *LevelContext, root *CharacterRoot) ([]Vocabulary, error) {
+	viVocabs, err := ctx.VocabulariesByLanguage(root.ID, "vi")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Vocabulary
+	for _, vocab := range viVocabs {
+		if len(vocab.HanVietSyllables()) > 0 {
+			result = append(result, vocab)
+		}
+	}
+	return result, nil
+}
+
+// hanVietKoreanCognate 与越南语词对应同一个中文词的韩语汉字词，没有时返回 nil
+func hanVietKoreanCognate(ctx *LevelContext, vocab Vocabulary) (*Vocabulary, error) {
+	koVocabs, err := ctx.Repo.GetVocabulariesByLanguage("ko")
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range CognateSets(append(koVocabs, vocab), "ko", "vi") {
+		if set.Chinese == vocab.Chinese {
+			ko := set.Words["ko"]
+			return &ko, nil
+		}
+	}
+	return nil, nil
+}
+
+// hanVietHint 音节的提示：有韩语同源词时对照韩语的读音，否则对照普通话读音
+func hanVietHint(ctx *LevelContext, s HanVietSyllable, korean *Vocabulary) (string, error) {
+	if korean != nil {
+		syllables := korean.HanjaSyllables()
+		if s.Position < len(syllables) && syllables[s.Position].Reading != "" {
+			return fmt.Sprintf("对比韩语：%s 里的「%s」读 %s（%s），汉越音和韩语汉字音常常很像",
+				korean.Word, s.Chinese, syllables[s.Position].Hangul, syllables[s.Position].Reading), nil
+		}
+	}
+
+	if s.RootID != 0 {
+		root, err := ctx.Repo.GetRootByID(s.RootID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("「%s」的普通话读音是 %s，汉越音的声调往往和中古汉语的四声对应", s.Chinese, root.Pinyin), nil
+	}
+	return "先排除声母和韵母不对的选项，再看声调符号", nil
+}
@@ -0,0 +1,120 @@
+package hanbao
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LevelKey 确定一个关卡的全部参数，内容不变时同一个 LevelKey 总是生成相同的关卡，
+// 因此关卡ID可以用来重新生成关卡验证答案，也可以分享给同学挑战同一关
+type LevelKey struct {
+	Type       string // 关卡类型名称或别名
+	RootID     int64
+	Difficulty int
+	Seed       uint64 // 随机种子，0 表示未指定，生成时由 LevelSigner 签发
+}
+
+// String 关卡ID，格式: 类型_字根ID_难度_种子，种子为36进制
+func (k LevelKey) String() string {
+	return fmt.Sprintf("%s_%d_%d_%s", k.Type, k.RootID, k.Difficulty, strconv.FormatUint(k.Seed, 36))
+}
+
+// ParseLevelID 解析关卡ID，支持 类型_字根ID_难度_种子，以及省略种子的 类型_字根ID_难度；
+// 类型名称中可以包含下划线
+func ParseLevelID(levelID string) (LevelKey, error) {
+	parts := strings.Split(levelID, "_")
+
+	if n := len(parts); n >= 4 {
+		if key, err := parseLevelKey(parts[:n-3], parts[n-3], parts[n-2]); err == nil {
+			if seed, err := strconv.ParseUint(parts[n-1], 36, 64); err == nil && seed != 0 {
+				key.Seed = seed
+				return key, nil
+			}
+		}
+	}
+	if n := len(parts); n >= 3 {
+		if key, err := parseLevelKey(parts[:n-2], parts[n-2], parts[n-1]); err == nil {
+			return key, nil
+		}
+	}
+
+	return LevelKey{}, ErrInvalidLevelID.WithDetails(map[string]any{"level_id": levelID})
+}
+
+// parseLevelKey 解析关卡ID中的类型、字根ID和难度
+func parseLevelKey(typeParts []string, rootID, difficulty string) (LevelKey, error) {
+	key := LevelKey{Type: strings.Join(typeParts, "_")}
+	if key.Type == "" {
+		return key, ErrInvalidLevelID
+	}
+
+	var err error
+	if key.RootID, err = strconv.ParseInt(rootID, 10, 64); err != nil {
+		return key, err
+	}
+	if key.Difficulty, err = strconv.Atoi(difficulty); err != nil {
+		return key, err
+	}
+	return key, nil
+}
+
+// LevelSigner 签发和校验关卡种子：种子的高32位随机，低32位是关卡参数和高32位的 HMAC 签名，
+// 关卡ID的格式不变，但只有服务端签发的种子才能生成关卡和答题，客户端不能挑选或伪造种子
+type LevelSigner struct {
+	key []byte
+}
+
+// NewLevelSigner 根据密钥创建关卡种子签名器，secret 为空时使用随机密钥，
+// 签发的关卡ID只在本进程内有效；多个实例共享答题记录时必须配置相同的密钥
+func NewLevelSigner(secret string) (*LevelSigner, error) {
+	if secret == "" {
+		var buf [32]byte
+		if _, err := rand.Read(buf[:]); err != nil {
+			return nil, err
+		}
+		return &LevelSigner{key: buf[:]}, nil
+	}
+	if len(secret) < 16 {
+		return nil, errors.New("关卡签名密钥至少需要16个字符")
+	}
+
+	return &LevelSigner{key: deriveKey(secret, "hanbao-level-seed")}, nil
+}
+
+// Seed 为关卡参数签发不为0的随机种子，key 应为 resolveKey 规范后的参数，可以并发调用
+func (s *LevelSigner) Seed(key LevelKey) uint64 {
+	var buf [4]byte
+	for {
+		if _, err := rand.Read(buf[:]); err != nil {
+			panic(err)
+		}
+		if nonce := binary.BigEndian.Uint32(buf[:]); nonce != 0 {
+			return uint64(nonce)<<32 | uint64(binary.BigEndian.Uint32(s.sign(key, nonce)))
+		}
+	}
+}
+
+// Verify 校验种子是否由本签名器为这组关卡参数签发
+func (s *LevelSigner) Verify(key LevelKey) bool {
+	nonce := uint32(key.Seed >> 32)
+	if nonce == 0 {
+		return false
+	}
+
+	var signature [4]byte
+	binary.BigEndian.PutUint32(signature[:], uint32(key.Seed))
+	return hmac.Equal(signature[:], s.sign(key, nonce))
+}
+
+// sign 关卡参数和随机数的签名，取 HMAC-SHA256 的前4个字节
+func (s *LevelSigner) sign(key LevelKey, nonce uint32) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s_%d_%d_%d", key.Type, key.RootID, key.Difficulty, nonce)
+	return mac.Sum(nil)[:4]
+}
@@ -0,0 +1,89 @@
+package hanbao
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterLevelGenerator(listeningLevel{})
+}
+
+// listeningLevel 韩语听力侦探：在一段韩语中找出源于中文的词汇
+type listeningLevel struct{}
+
+// Metadata 关卡类型元数据
+func (listeningLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "listening",
+		Aliases:      []string{"listen"},
+		Title:        "韩语听力侦探",
+		Icon:         "🎧",
+		Description:  "在韩语中听出源于中文的汉字词",
+		TimeLimit:    240, // 4分钟
+		Score:        150,
+		RequiredData: []string{"vocabulary:ko"},
+	}
+}
+
+// Available 字根至少需要3个韩语词汇
+func (listeningLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	koVocabs, err := ctx.VocabulariesByLanguage(root.ID, "ko")
+	if err != nil {
+		return false, err
+	}
+	return len(koVocabs) >= 3, nil
+}
+
+// Generate 生成韩语听力侦探关卡
+func (g listeningLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	// 获取相关的韩语词汇
+	koVocabs, err := ctx.VocabulariesByLanguage(root.ID, "ko")
+	if err != nil {
+		return nil, err
+	}
+	if len(koVocabs) < 3 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "ko"})
+	}
+
+	// 随机选择词汇，难度越高词汇越多，难度1至少3个
+	count := min(max(3, difficulty+2), len(koVocabs))
+	selectedVocabs := make([]Vocabulary, 0, count)
+	usedIndices := make(map[int]bool)
+	for len(selectedVocabs) < count && len(usedIndices) < len(koVocabs) {
+		idx := ctx.Rand.Intn(len(koVocabs))
+		if !usedIndices[idx] {
+			usedIndices[idx] = true
+			selectedVocabs = append(selectedVocabs, koVocabs[idx])
+		}
+	}
+
+	// 构建问题内容
+	var vocabList strings.Builder
+	vocabularyIDs := make([]int64, len(selectedVocabs))
+	for i, vocab := range selectedVocabs {
+		if DifficultyShowsReading(difficulty) {
+			vocabList.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, vocab.Word, vocab.Pronunciation))
+		} else {
+			vocabList.WriteString(fmt.Sprintf("%d. %s\n", i+1, vocab.Word))
+		}
+		vocabularyIDs[i] = vocab.ID
+	}
+
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("在韩语中寻找\"%s\"的身影", root.Root)
+	level.Questions = []Question{
+		{
+			ID:   "q1",
+			Type: "text_input",
+			Content: fmt.Sprintf("请聆听这段韩语内容，圈出你听到的、像中文的词汇：\n\n%s\n\n你听到了几个像中文的词？",
+				vocabList.String()),
+			CorrectAnswer: fmt.Sprintf("%d", len(selectedVocabs)),
+			Hint:          ctx.Hint(difficulty, "韩语70%正式词汇是汉字词，听起来很熟悉"),
+			Explanation:   fmt.Sprintf("韩语中的汉字词直接借用汉字的音和义，%s相关的词汇都源于中文", root.Root),
+			VocabularyIDs: vocabularyIDs,
+		},
+	}
+
+	return level, nil
+}
@@ -0,0 +1,96 @@
+package hanbao
+
+import "fmt"
+
+func init() {
+	RegisterLevelGenerator(pronunciationLevel{})
+}
+
+// pronunciationLevel 音读破译室：比较同一字根在两个日语词中的读音
+type pronunciationLevel struct{}
+
+// Metadata 关卡类型元数据
+func (pronunciationLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "pronunciation",
+		Aliases:      []string{"pron"},
+		Title:        "音读破译室",
+		Icon:         "🔊",
+		Description:  "比较同一个汉字在日语词中的音读，发现古汉语的读音层次",
+		TimeLimit:    180, // 3分钟
+		Score:        100,
+		RequiredData: []string{"vocabulary:ja"},
+	}
+}
+
+// Available 字根至少需要2个日语词汇
+func (pronunciationLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	jaVocabs, err := ctx.VocabulariesByLanguage(root.ID, "ja")
+	if err != nil {
+		return false, err
+	}
+	return len(jaVocabs) >= 2, nil
+}
+
+// Generate 生成音读破译室关卡
+func (g pronunciationLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	// 获取相关的日语词汇
+	jaVocabs, err := ctx.VocabulariesByLanguage(root.ID, "ja")
+	if err != nil {
+		return nil, err
+	}
+	if len(jaVocabs) < 2 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "ja"})
+	}
+
+	// 随机选择两个词汇，一个给出读音作为参照，一个作为题目
+	vocab1 := jaVocabs[ctx.Rand.Intn(len(jaVocabs))]
+	var vocab2 Vocabulary
+	for {
+		vocab2 = jaVocabs[ctx.Rand.Intn(len(jaVocabs))]
+		if vocab2.ID != vocab1.ID {
+			break
+		}
+	}
+
+	distractors, err := ctx.Distractors().ReadingDistractors(vocab2, map[int64]bool{vocab1.ID: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// 高难度不给参照词的读音
+	content := fmt.Sprintf("「%s」应该怎么读？", vocab2.Word)
+	if DifficultyShowsReading(difficulty) {
+		content = fmt.Sprintf("已知「%s」读作 %s，那么「%s」应该怎么读？", vocab1.Word, romanized(vocab1), vocab2.Word)
+	}
+
+	correct := romanized(vocab2)
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("探索\"%s\"在日语中的发音奥秘", root.Root)
+	level.Questions = []Question{
+		{
+			ID:            "q1",
+			Type:          "multiple_choice",
+			Content:       content,
+			Options:       ctx.Options(correct, distractors, difficulty),
+			CorrectAnswer: correct,
+			Hint:          ctx.Hint(difficulty, fmt.Sprintf("中文\"%s\"的读音在日语中往往有迹可循", root.Root)),
+			Explanation:   readingExplanation(vocab2, root),
+			VocabularyIDs: []int64{vocab1.ID, vocab2.ID},
+		},
+	}
+
+	return level, nil
+}
+
+// readingExplanation 根据读音类型解释词汇的读法
+func readingExplanation(vocab Vocabulary, root *CharacterRoot) string {
+	switch vocab.ReadType {
+	case "on":
+		return fmt.Sprintf("「%s」读作 %s，\"%s\"在这里是音读，沿用了汉字传入日本时的古汉语读音", vocab.Word, romanized(vocab), root.Root)
+	case "kun":
+		return fmt.Sprintf("「%s」读作 %s，\"%s\"在这里是训读，用日语固有的词来读这个汉字", vocab.Word, romanized(vocab), root.Root)
+	default:
+		return fmt.Sprintf("「%s」读作 %s", vocab.Word, romanized(vocab))
+	}
+}
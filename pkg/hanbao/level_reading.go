@@ -0,0 +1,166 @@
+package hanbao
+
+import "fmt"
+
+func init() {
+	RegisterLevelGenerator(readingLevel{})
+}
+
+// 读音类型的选项
+const (
+	onReadingOption  = "音读"
+	kunReadingOption = "训读"
+)
+
+// mixedReadingDistractors 高难度时加入的混读类型干扰项
+var mixedReadingDistractors = []Distractor{
+	{Text: "重箱读（音读+训读）", Source: DistractorWrongReadType},
+	{Text: "汤桶读（训读+音读）", Source: DistractorWrongReadType},
+}
+
+// readingLevel 音训读辨析：选出日语词的读音，并判断是音读还是训读
+type readingLevel struct{}
+
+// Metadata 关卡类型元数据
+func (readingLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "reading",
+		Aliases:      []string{"onkun"},
+		Title:        "音训读辨析",
+		Icon:         "📖",
+		Description:  "选出日语词的正确读音，分辨音读和训读，找到音读与中文读音的联系",
+		TimeLimit:    150,
+		Score:        120,
+		RequiredData: []string{"vocabulary:ja"},
+	}
+}
+
+// Available 字根至少需要1个标注了音读或训读的日语词汇
+func (readingLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	vocabs, err := readingVocabularies(ctx, root)
+	if err != nil {
+		return false, err
+	}
+	return len(vocabs) > 0, nil
+}
+
+// Generate 生成音训读辨析关卡
+func (g readingLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	vocabs, err := readingVocabularies(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(vocabs) == 0 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "ja"})
+	}
+	vocab := vocabs[ctx.Rand.Intn(len(vocabs))]
+
+	distractors, err := ctx.Distractors().ReadingDistractors(vocab, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := readingLink(ctx, root, vocab)
+	if err != nil {
+		return nil, err
+	}
+
+	correctType, otherType := onReadingOption, kunReadingOption
+	if vocab.ReadType == "kun" {
+		correctType, otherType = kunReadingOption, onReadingOption
+	}
+	typeDistractors := []Distractor{{Text: otherType, Source: DistractorWrongReadType}}
+	if !DifficultyShowsHint(difficulty) {
+		typeDistractors = append(typeDistractors, mixedReadingDistractors...)
+	}
+
+	content := fmt.Sprintf("「%s」应该怎么读？", vocab.Word)
+	if DifficultyShowsReading(difficulty) {
+		content = fmt.Sprintf("「%s」（意思是 %s）应该怎么读？", vocab.Word, vocab.Meaning)
+	}
+
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("分辨\"%s\"在日语中的音读和训读", root.Root)
+	level.Questions = []Question{
+		{
+			ID:            "q1",
+			Type:          "multiple_choice",
+			Content:       content,
+			Options:       ctx.Options(romanized(vocab), distractors, difficulty),
+			CorrectAnswer: romanized(vocab),
+			Hint:          ctx.Hint(difficulty, "音读通常用于两个汉字组成的词，单独一个汉字成词时多用训读"),
+			Explanation:   fmt.Sprintf("「%s」读作 %s（%s）", vocab.Word, romanized(vocab), vocab.Pronunciation),
+			VocabularyIDs: []int64{vocab.ID},
+		},
+		// q2 的题面和提示不能出现读音，否则会泄露 q1 的答案
+		{
+			ID:            "q2",
+			Type:          "multiple_choice",
+			Content:       fmt.Sprintf("上一题中「%s」的读音是音读还是训读？", vocab.Word),
+			Options:       ctx.Options(correctType, typeDistractors, difficulty),
+			CorrectAnswer: correctType,
+			Hint:          ctx.Hint(difficulty, fmt.Sprintf("想一想这个读音和中文\"%s\"（%s）的读音像不像", root.Root, root.Pinyin)),
+			Explanation:   link,
+		},
+	}
+
+	return level, nil
+}
+
+// readingVocabularies 字根下标注了读音类型、可以用罗马字出题的日语词汇
+func readingVocabularies(ctx *LevelContext, root *CharacterRoot) ([]Vocabulary, error) {
+	jaVocabs, err := ctx.VocabulariesByLanguage(root.ID, "ja")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Vocabulary
+	for _, vocab := range jaVocabs {
+		if (vocab.ReadType == "on" || vocab.ReadType == "kun") && romanized(vocab) != "" {
+			result = append(result, vocab)
+		}
+	}
+	return result, nil
+}
+
+// readingLink 解释读音与中文读音的关系：音读与中文、韩语读音同源，训读是日语固有词
+func readingLink(ctx *LevelContext, root *CharacterRoot, vocab Vocabulary) (string, error) {
+	vocabs, err := ctx.Repo.GetVocabulariesByRoot(root.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if vocab.ReadType == "kun" {
+		for _, v := range vocabs {
+			if v.Language == "ja" && v.ReadType == "on" {
+				return fmt.Sprintf("%s 是训读：借用汉字\"%s\"来写日语固有的词，读音与中文的 %s 无关；"+
+					"对比音读词「%s」（%s），那里的读音才与中文同源", romanized(vocab), root.Root, root.Pinyin,
+					v.Word, romanized(v)), nil
+			}
+		}
+		return fmt.Sprintf("%s 是训读：借用汉字\"%s\"来写日语固有的词，读音与中文的 %s 无关",
+			romanized(vocab), root.Root, root.Pinyin), nil
+	}
+
+	// 优先用意思相同的韩语词做对照，如 学生 / 학생
+	var cognate *Vocabulary
+	for i, v := range vocabs {
+		if v.Language != "ko" {
+			continue
+		}
+		if sameMeaning(v.Meaning, vocab.Meaning) {
+			cognate = &vocabs[i]
+			break
+		}
+		if cognate == nil {
+			cognate = &vocabs[i]
+		}
+	}
+
+	link := fmt.Sprintf("%s 是音读：沿用了汉字传入日本时的古汉语读音，与普通话\"%s\"的读音 %s 同出一源",
+		romanized(vocab), root.Root, root.Pinyin)
+	if cognate != nil {
+		link += fmt.Sprintf("，韩语的 %s（%s）也保留了同一个古音", cognate.Word, cognate.Pronunciation)
+	}
+	return link, nil
+}
@@ -1,286 +1,416 @@
 package hanbao
 
 import (
-	"fmt"
+	"errors"
 	"math/rand"
 	"strings"
 	"time"
+
+	"golang.org/x/text/unicode/norm"
 )
 
-// LevelService 关卡服务
+// LevelService 关卡服务，可以并发使用
 type LevelService struct {
-	roots         []CharacterRoot
-	vocabularies  []Vocabulary
-	dialectExamples []DialectExample
-	rng           *rand.Rand
+	repo     ContentRepository
+	store    LevelStore
+	registry *LevelRegistry
+	signer   *LevelSigner // 签发和校验关卡ID中的种子
+
+	tokens      *AnswerTokenCodec // 不为空时关卡携带加密的答案令牌
+	tokenExpire time.Duration
 }
 
-// NewLevelService 创建关卡服务
-func NewLevelService() *LevelService {
+// NewLevelService 创建关卡服务，答题记录保存在 store 中，防止同一问题重复提交；
+// 关卡ID中的种子由 signer 签发，只有签发过的关卡ID才能重新生成和答题
+func NewLevelService(repo ContentRepository, store LevelStore, signer *LevelSigner) *LevelService {
 	return &LevelService{
-		roots:           CharacterRootsData,
-		vocabularies:    VocabularyData,
-		dialectExamples: DialectExamplesData,
-		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		repo:     repo,
+		store:    store,
+		registry: DefaultLevelRegistry,
+		signer:   signer,
 	}
 }
 
-// GenerateLevel 生成关卡
-func (s *LevelService) GenerateLevel(levelType string, rootID int64, difficulty int) (*Level, error) {
-	switch levelType {
-	case "pronunciation":
-		return s.generatePronunciationLevel(rootID, difficulty)
-	case "listening":
-		return s.generateListeningLevel(rootID, difficulty)
-	case "dialect":
-		return s.generateDialectLevel(rootID, difficulty)
-	default:
-		return nil, fmt.Errorf("不支持的关卡类型: %s", levelType)
+// NewStatelessLevelService 创建无状态关卡服务，标准答案加密在关卡的答案令牌中，
+// 验证答案不依赖关卡存储，同一问题是否已作答由会话记录（见 SessionService.RecordAnswer）
+func NewStatelessLevelService(repo ContentRepository, tokens *AnswerTokenCodec, signer *LevelSigner, expire time.Duration) *LevelService {
+	return &LevelService{
+		repo:        repo,
+		registry:    DefaultLevelRegistry,
+		signer:      signer,
+		tokens:      tokens,
+		tokenExpire: expire,
 	}
 }
 
-// generatePronunciationLevel 生成音读破译室关卡
-func (s *LevelService) generatePronunciationLevel(rootID int64, difficulty int) (*Level, error) {
-	root := s.findRootByID(rootID)
-	if root == nil {
-		return nil, fmt.Errorf("字根不存在: %d", rootID)
+// GenerateLevel 使用随机种子生成关卡，levelType 可以是关卡类型名称或别名，difficulty 超出 1-5 时取最近的有效值
+func (s *LevelService) GenerateLevel(levelType string, rootID int64, difficulty int) (*Level, error) {
+	return s.GenerateLevelFromKey(LevelKey{Type: levelType, RootID: rootID, Difficulty: difficulty})
+}
+
+// GenerateLevelFromKey 按关卡参数和种子生成关卡，种子为0时签发新的种子，不是本服务签发的种子返回 ErrLevelNotIssued；
+// 种子相同且内容不变时生成的关卡（包括关卡ID、问题和选项顺序）完全相同
+func (s *LevelService) GenerateLevelFromKey(key LevelKey) (*Level, error) {
+	gen, key, err := s.resolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if key.Seed == 0 {
+		key.Seed = s.signer.Seed(key)
+	} else if err := s.verifySeed(key); err != nil {
+		return nil, err
 	}
 
-	// 获取相关的日语词汇
-	jaVocabs := s.getVocabulariesByRootAndLanguage(rootID, "ja")
-	if len(jaVocabs) < 2 {
-		return nil, fmt.Errorf("字根 %s 没有足够的日语词汇数据", root.Root)
+	root, err := s.findRootByID(key.RootID)
+	if err != nil {
+		return nil, err
 	}
 
-	// 随机选择两个词汇进行比较
-	vocab1 := jaVocabs[s.rng.Intn(len(jaVocabs))]
-	var vocab2 Vocabulary
-	for {
-		vocab2 = jaVocabs[s.rng.Intn(len(jaVocabs))]
-		if vocab2.ID != vocab1.ID {
-			break
-		}
+	level, err := gen.Generate(s.levelContext(key.Seed), root, key.Difficulty)
+	if err != nil {
+		return nil, err
 	}
 
-	questions := []Question{
-		{
-			ID:   fmt.Sprintf("pron_%d_1", time.Now().Unix()),
-			Type: "multiple_choice",
-			Content: fmt.Sprintf("这两个日语词中相同的\"%s\"，读音有何规律？\n• %s（%s）\n• %s（%s）",
-				root.Root, vocab1.Word, vocab1.Romaji, vocab2.Word, vocab2.Romaji),
-			Options: []string{
-				"模仿了古汉语的不同方言层次",
-				"完全相同的发音",
-				"现代汉语的标准发音",
-				"随机的发音变化",
-			},
-			CorrectAnswer: "模仿了古汉语的不同方言层次",
-			Hint:         fmt.Sprintf("中文\"%s\"在不同语境下的发音差异", root.Root),
-			Explanation:  fmt.Sprintf("日语中的汉字词继承了中国古代汉语的读音层次，反映了历史上的语言演变"),
-		},
-	}
-
-	level := &Level{
-		ID:          fmt.Sprintf("pron_%d_%d", rootID, time.Now().Unix()),
-		Type:        "pronunciation",
-		Title:       "音读破译室 🔊",
-		Description: fmt.Sprintf("探索\"%s\"在日语中的发音奥秘", root.Root),
-		RootID:      rootID,
-		Difficulty:  difficulty,
-		TimeLimit:   180, // 3分钟
-		Questions:   questions,
-		Reward: Reward{
-			Roots: []int64{rootID},
-			Score: 100,
-		},
-		CreatedAt: time.Now(),
+	shuffleOptions(level)
+	assignQuestionScores(level)
+
+	if s.tokens != nil {
+		token, err := s.tokens.Seal(NewAnswerKey(level, level.CreatedAt.Add(s.tokenExpire)))
+		if err != nil {
+			return nil, err
+		}
+		level.AnswerToken = token
 	}
 
 	return level, nil
 }
 
-// generateListeningLevel 生成韩语听力侦探关卡
-func (s *LevelService) generateListeningLevel(rootID int64, difficulty int) (*Level, error) {
-	root := s.findRootByID(rootID)
-	if root == nil {
-		return nil, fmt.Errorf("字根不存在: %d", rootID)
+// RegenerateLevel 根据关卡ID重新生成关卡，关卡ID必须包含种子
+func (s *LevelService) RegenerateLevel(levelID string) (*Level, error) {
+	key, err := ParseLevelID(levelID)
+	if err != nil {
+		return nil, err
 	}
-
-	// 获取相关的韩语词汇
-	koVocabs := s.getVocabulariesByRootAndLanguage(rootID, "ko")
-	if len(koVocabs) < 3 {
-		return nil, fmt.Errorf("字根 %s 没有足够的韩语词汇数据", root.Root)
+	if key.Seed == 0 {
+		return nil, ErrInvalidLevelID.WithDetails(map[string]any{"level_id": levelID})
 	}
+	return s.GenerateLevelFromKey(key)
+}
 
-	// 随机选择3个词汇
-	selectedVocabs := make([]Vocabulary, 0, 3)
-	usedIndices := make(map[int]bool)
-	for len(selectedVocabs) < 3 && len(usedIndices) < len(koVocabs) {
-		idx := s.rng.Intn(len(koVocabs))
-		if !usedIndices[idx] {
-			usedIndices[idx] = true
-			selectedVocabs = append(selectedVocabs, koVocabs[idx])
-		}
+// verifySeed 校验关卡参数中的种子是否由本服务签发，客户端不能自己挑选种子
+func (s *LevelService) verifySeed(key LevelKey) error {
+	if !s.signer.Verify(key) {
+		return ErrLevelNotIssued.WithDetails(map[string]any{"level_id": key.String()})
 	}
+	return nil
+}
 
-	// 构建问题内容
-	var vocabList strings.Builder
-	for i, vocab := range selectedVocabs {
-		vocabList.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, vocab.Word, vocab.Pronunciation))
-	}
-
-	questions := []Question{
-		{
-			ID:   fmt.Sprintf("listen_%d_1", time.Now().Unix()),
-			Type: "text_input",
-			Content: fmt.Sprintf("请聆听这段韩语内容，圈出你听到的、像中文的词汇：\n\n%s\n\n你听到了几个像中文的词？",
-				vocabList.String()),
-			CorrectAnswer: fmt.Sprintf("%d", len(selectedVocabs)),
-			Hint:         "韩语70%正式词汇是汉字词，听起来很熟悉",
-			Explanation:  fmt.Sprintf("韩语中的汉字词直接借用汉字的音和义，%s相关的词汇都源于中文", root.Root),
-		},
-	}
-
-	level := &Level{
-		ID:          fmt.Sprintf("listen_%d_%d", rootID, time.Now().Unix()),
-		Type:        "listening",
-		Title:       "韩语听力侦探 🎧",
-		Description: fmt.Sprintf("在韩语中寻找\"%s\"的身影", root.Root),
-		RootID:      rootID,
-		Difficulty:  difficulty,
-		TimeLimit:   240, // 4分钟
-		Questions:   questions,
-		Reward: Reward{
-			Roots: []int64{rootID},
-			Score: 150,
-		},
-		CreatedAt: time.Now(),
+// resolveKey 查找关卡类型的生成器，并将关卡参数规范为生成的关卡ID中使用的形式
+func (s *LevelService) resolveKey(key LevelKey) (LevelGenerator, LevelKey, error) {
+	gen, ok := s.registry.Lookup(key.Type)
+	if !ok {
+		return nil, key, ErrUnsupportedLevelType.WithDetails(map[string]any{"level_type": key.Type})
 	}
 
-	return level, nil
+	key.Type = gen.Metadata().idPrefix()
+	key.Difficulty = normalizeDifficulty(key.Difficulty)
+	return gen, key, nil
+}
+
+// LevelTypeInfo 关卡类型信息及可用的字根
+type LevelTypeInfo struct {
+	LevelTypeMetadata
+	AvailableRoots []int64 `json:"available_roots"` // 有足够数据生成该关卡的字根
 }
 
-// generateDialectLevel 生成方言连接彩蛋关卡
-func (s *LevelService) generateDialectLevel(rootID int64, difficulty int) (*Level, error) {
-	root := s.findRootByID(rootID)
-	if root == nil {
-		return nil, fmt.Errorf("字根不存在: %d", rootID)
+// ListLevelTypes 列出所有已注册的关卡类型，并检查每个字根是否可以生成该关卡
+func (s *LevelService) ListLevelTypes() ([]LevelTypeInfo, error) {
+	roots, err := s.repo.ListRoots()
+	if err != nil {
+		return nil, err
 	}
 
-	// 查找相关的方言例子
-	var dialectExample *DialectExample
-	for _, example := range s.dialectExamples {
-		if example.RootID == rootID {
-			dialectExample = &example
-			break
+	ctx := s.levelContext(0)
+	generators := s.registry.List()
+	result := make([]LevelTypeInfo, len(generators))
+	for i, gen := range generators {
+		info := LevelTypeInfo{
+			LevelTypeMetadata: gen.Metadata(),
+			AvailableRoots:    []int64{},
 		}
+		for j := range roots {
+			available, err := gen.Available(ctx, &roots[j])
+			if err != nil {
+				return nil, err
+			}
+			if available {
+				info.AvailableRoots = append(info.AvailableRoots, roots[j].ID)
+			}
+		}
+		result[i] = info
 	}
 
-	if dialectExample == nil {
-		return nil, fmt.Errorf("字根 %s 没有方言数据", root.Root)
-	}
-
-	questions := []Question{
-		{
-			ID:   fmt.Sprintf("dialect_%d_1", time.Now().Unix()),
-			Type: "multiple_choice",
-			Content: fmt.Sprintf("用你的方言说\"%s\"，会怎么说？\n\n标准汉语：%s\n%s方言：%s",
-				dialectExample.Standard, dialectExample.Standard, dialectExample.DialectType, dialectExample.Dialect),
-			Options: []string{
-				fmt.Sprintf("与%s发音相似", dialectExample.Dialect),
-				"完全不同",
-				"标准汉语发音",
-				"现代普通话发音",
-			},
-			CorrectAnswer: fmt.Sprintf("与%s发音相似", dialectExample.Dialect),
-			Hint:         "汉字读音是一部活的迁徙史",
-			Explanation:  dialectExample.Description,
-		},
-	}
-
-	level := &Level{
-		ID:          fmt.Sprintf("dialect_%d_%d", rootID, time.Now().Unix()),
-		Type:        "dialect",
-		Title:       "方言连接彩蛋 🗺️",
-		Description: fmt.Sprintf("探索\"%s\"的方言奥秘", root.Root),
-		RootID:      rootID,
-		Difficulty:  difficulty,
-		TimeLimit:   120, // 2分钟
-		Questions:   questions,
-		Reward: Reward{
-			Roots: []int64{rootID},
-			Score: 80,
-		},
-		CreatedAt: time.Now(),
+	return result, nil
+}
+
+// LevelOption 可以为某个字根生成的关卡类型
+type LevelOption struct {
+	Type string
+	Root CharacterRoot
+}
+
+// AvailableLevels 列出这些字根可以生成的所有关卡类型，不存在的字根会被跳过
+func (s *LevelService) AvailableLevels(rootIDs []int64) ([]LevelOption, error) {
+	ctx := s.levelContext(0)
+	generators := s.registry.List()
+
+	var result []LevelOption
+	for _, rootID := range rootIDs {
+		root, err := s.repo.GetRootByID(rootID)
+		if errors.Is(err, ErrContentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, gen := range generators {
+			available, err := gen.Available(ctx, root)
+			if err != nil {
+				return nil, err
+			}
+			if available {
+				result = append(result, LevelOption{Type: gen.Metadata().Type, Root: *root})
+			}
+		}
 	}
 
-	return level, nil
+	return result, nil
 }
 
-// ValidateAnswer 验证答案
-func (s *LevelService) ValidateAnswer(levelID string, questionID string, userAnswer string) (*AnswerResult, error) {
-	// 这里简化处理，实际应该从存储中获取关卡数据
-	// 现在直接返回正确的结果用于演示
-
-	return &AnswerResult{
-		Correct:     true,
-		Score:       100,
-		Explanation: "回答正确！汉字的语言演变史真是迷人",
-		NextHint:    "继续探索更多汉字词根的奥秘",
-	}, nil
+// levelContext 创建生成关卡使用的上下文，每次调用都使用独立的随机数生成器
+func (s *LevelService) levelContext(seed uint64) *LevelContext {
+	return &LevelContext{
+		Repo: s.repo,
+		Rand: rand.New(rand.NewSource(int64(seed))),
+		Seed: seed,
+	}
 }
 
-// AnswerResult 答案验证结果
-type AnswerResult struct {
-	Correct     bool   `json:"correct"`
-	Score       int    `json:"score"`
-	Explanation string `json:"explanation"`
-	NextHint    string `json:"next_hint,omitempty"`
+// assignQuestionScores 将关卡奖励分数平均分配到每个问题，余数计入最后一题
+func assignQuestionScores(level *Level) {
+	n := len(level.Questions)
+	if n == 0 {
+		return
+	}
+
+	each := level.Reward.Score / n
+	for i := range level.Questions {
+		level.Questions[i].Score = each
+	}
+	level.Questions[n-1].Score += level.Reward.Score - each*n
 }
 
-// Helper methods
-func (s *LevelService) findRootByID(rootID int64) *CharacterRoot {
-	for _, root := range s.roots {
-		if root.ID == rootID {
-			return &root
+// ValidateAnswer 验证答案，关卡根据ID重新生成，同一关卡可以分享给同学挑战。
+// 带会话时答题记录按会话保存，同一会话中每个问题只能提交一次；
+// 匿名答题只验证对错，不写入答题记录，也不会影响其他人回答同一关卡；
+// 匿名答题不公开标准答案和解释，否则可以先匿名试出答案再带会话提交得分
+func (s *LevelService) ValidateAnswer(levelID, sessionID, questionID, userAnswer string) (*AnswerResult, error) {
+	if s.store == nil {
+		return nil, ErrAnswerTokenRequired
+	}
+
+	key, err := ParseLevelID(levelID)
+	if err != nil {
+		return nil, err
+	}
+	_, key, err = s.resolveKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if key.Seed == 0 {
+		return nil, ErrInvalidLevelID.WithDetails(map[string]any{"level_id": levelID})
+	}
+	if err := s.verifySeed(key); err != nil {
+		return nil, err
+	}
+	levelID = key.String()
+
+	if sessionID == "" {
+		level, err := s.regenerateForAnswer(key)
+		if err != nil {
+			return nil, err
 		}
+		question, err := findQuestion(level, questionID)
+		if err != nil {
+			return nil, err
+		}
+		result := newAnswerResult(level, question, userAnswer)
+		result.hideSolution()
+		return result, nil
 	}
-	return nil
+
+	// 已有答题记录时直接使用保存的关卡，否则重新生成
+	instance, err := s.store.Get(levelID, sessionID)
+	if errors.Is(err, ErrLevelNotFound) {
+		level, err := s.regenerateForAnswer(key)
+		if err != nil {
+			return nil, err
+		}
+		instance = &LevelInstance{
+			Level:     *level,
+			SessionID: sessionID,
+			Answers:   make(map[string]AnswerRecord),
+			CreatedAt: time.Now(),
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	question, err := findQuestion(&instance.Level, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, answered := instance.Answers[questionID]; answered {
+		return nil, ErrAnswerAlreadySubmitted
+	}
+
+	// 由存储原子地写入答题记录，并发提交同一问题时只有一次成功
+	result := newAnswerResult(&instance.Level, question, userAnswer)
+	answered, err := s.store.RecordAnswer(instance, questionID, AnswerRecord{
+		Answer:     userAnswer,
+		Correct:    result.Correct,
+		Score:      result.Score,
+		AnsweredAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.LevelCompleted = answered >= len(instance.Level.Questions)
+	return result, nil
 }
 
-func (s *LevelService) getVocabulariesByRootAndLanguage(rootID int64, language string) []Vocabulary {
-	var result []Vocabulary
-	for _, vocab := range s.vocabularies {
-		if vocab.RootID == rootID && vocab.Language == language {
-			result = append(result, vocab)
+// regenerateForAnswer 根据关卡参数重新生成答题的关卡，字根或词汇已不存在时关卡无法还原，返回 ErrLevelNotFound
+func (s *LevelService) regenerateForAnswer(key LevelKey) (*Level, error) {
+	level, err := s.GenerateLevelFromKey(key)
+	if errors.Is(err, ErrRootNotFound) || errors.Is(err, ErrInsufficientVocabulary) {
+		return nil, ErrLevelNotFound.WithDetails(map[string]any{"level_id": key.String()})
+	}
+	return level, err
+}
+
+// findQuestion 在关卡中查找问题
+func findQuestion(level *Level, questionID string) (*Question, error) {
+	for i := range level.Questions {
+		if level.Questions[i].ID == questionID {
+			return &level.Questions[i], nil
 		}
 	}
+	return nil, ErrQuestionNotFound
+}
+
+// newAnswerResult 比对答案并填写所属关卡的信息
+func newAnswerResult(level *Level, question *Question, userAnswer string) *AnswerResult {
+	result := checkAnswer(question, userAnswer)
+	result.LevelID = level.ID
+	result.LevelType = level.Type
+	result.RootID = level.RootID
+	result.Difficulty = level.Difficulty
 	return result
 }
 
-// GenerateSessionLevels 为用户会话生成关卡序列
-func (s *LevelService) GenerateSessionLevels(unlockedRoots []int64) ([]Level, error) {
-	if len(unlockedRoots) == 0 {
-		return nil, fmt.Errorf("没有可用的字根")
+// ValidateAnswerWithToken 使用关卡携带的答案令牌验证答案，不读取关卡存储；
+// 与 ValidateAnswer 相同，匿名答题（sessionID 为空）不公开标准答案和解释
+func (s *LevelService) ValidateAnswerWithToken(answerToken, levelID, sessionID, questionID, userAnswer string) (*AnswerResult, error) {
+	if s.tokens == nil {
+		return nil, ErrAnswerTokenDisabled
 	}
 
-	var levels []Level
-	levelCount := min(5, len(unlockedRoots)*2) // 每个字根最多2个关卡
+	key, err := s.tokens.Open(answerToken)
+	if err != nil {
+		return nil, err
+	}
+	if key.LevelID != levelID {
+		return nil, ErrInvalidAnswerToken
+	}
 
-	// 随机选择字根和关卡类型
-	levelTypes := []string{"pronunciation", "listening", "dialect"}
+	question, ok := key.Question(questionID)
+	if !ok {
+		return nil, ErrQuestionNotFound
+	}
 
-	for i := 0; i < levelCount; i++ {
-		rootID := unlockedRoots[s.rng.Intn(len(unlockedRoots))]
-		levelType := levelTypes[s.rng.Intn(len(levelTypes))]
+	result := checkAnswer(question, userAnswer)
+	result.LevelID = key.LevelID
+	result.LevelType = key.LevelType
+	result.RootID = key.RootID
+	result.Difficulty = key.Difficulty
+	for _, q := range key.Questions {
+		result.QuestionIDs = append(result.QuestionIDs, q.ID)
+	}
+	if sessionID == "" {
+		result.hideSolution()
+	}
+	return result, nil
+}
 
-		level, err := s.GenerateLevel(levelType, rootID, 1)
-		if err != nil {
-			continue // 跳过无法生成的关卡
-		}
+// hideSolution 去掉结果中的标准答案、解释和读音规律，只保留对错和得分
+func (r *AnswerResult) hideSolution() {
+	r.CorrectAnswer = ""
+	r.Explanation = ""
+	r.SoundRules = nil
+}
+
+// checkAnswer 比对答案并生成验证结果，答对时获得该题分值，连线题按连对的比例给分
+func checkAnswer(question *Question, userAnswer string) *AnswerResult {
+	if question.Type == QuestionTypeMatching {
+		return checkMatchingAnswer(question, userAnswer)
+	}
 
-		levels = append(levels, *level)
+	result := &AnswerResult{
+		Correct:       normalizeAnswer(userAnswer) == normalizeAnswer(question.CorrectAnswer),
+		CorrectAnswer: question.CorrectAnswer,
+		Explanation:   question.Explanation,
+		VocabularyIDs: question.VocabularyIDs,
+		SoundRules:    soundRulesByID(question.SoundRules, question.RuleLanguage),
 	}
+	if result.Correct {
+		result.Score = question.Score
+		result.NextHint = "回答正确！继续探索更多汉字词根的奥秘"
+	} else {
+		result.NextHint = question.Hint
+	}
+	return result
+}
 
-	return levels, nil
+// normalizeAnswer 规范化答案，忽略首尾空白和大小写，并统一为 NFC 形式，
+// 使分解形式输入的越南语声调（如 a + ◌̣）与预组合字符（ạ）一致
+func normalizeAnswer(answer string) string {
+	return norm.NFC.String(strings.ToLower(strings.TrimSpace(answer)))
+}
+
+// AnswerResult 答案验证结果
+type AnswerResult struct {
+	Correct        bool        `json:"correct"`
+	Score          int         `json:"score"`
+	CorrectAnswer  string      `json:"correct_answer"` // 带会话提交后才公开的标准答案，匿名答题时为空
+	Explanation    string      `json:"explanation"`
+	NextHint       string      `json:"next_hint,omitempty"`
+	LevelCompleted bool        `json:"level_completed"`          // 关卡的所有问题是否都已作答
+	QuestionIDs    []string    `json:"question_ids,omitempty"`   // 答案令牌中关卡的所有问题，会话据此判断关卡是否完成
+	VocabularyIDs  []int64     `json:"vocabulary_ids,omitempty"` // 题目涉及的词汇
+	SoundRules     []SoundRule `json:"sound_rules,omitempty"`    // 解释答案用到的读音对应规律
+	LevelID        string      `json:"level_id"`                 // 规范化的关卡ID，用于在会话中记录已作答的问题
+	LevelType      string      `json:"level_type"`               // 所属关卡的类型、字根和难度，用于更新能力评分
+	RootID         int64       `json:"root_id"`
+	Difficulty     int         `json:"difficulty"`
+}
+
+// Helper methods
+func (s *LevelService) findRootByID(rootID int64) (*CharacterRoot, error) {
+	root, err := s.repo.GetRootByID(rootID)
+	if errors.Is(err, ErrContentNotFound) {
+		return nil, ErrRootNotFound.WithDetails(map[string]any{"root_id": rootID})
+	}
+	return root, err
 }
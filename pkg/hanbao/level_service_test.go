@@ -0,0 +1,59 @@
+package hanbao
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLevelServiceRejectsUnissuedSeeds(t *testing.T) {
+	store, err := NewMemoryLevelStore(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewLevelSigner("a-test-secret-for-level-ids")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewLevelService(NewDefaultContentRepository(), store, signer)
+
+	level, err := svc.GenerateLevel("pron", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.RegenerateLevel(level.ID); err != nil {
+		t.Fatalf("RegenerateLevel(%q) error = %v", level.ID, err)
+	}
+	if _, err := svc.ValidateAnswer(level.ID, "s1", "q1", "x"); err != nil {
+		t.Fatalf("ValidateAnswer(%q) error = %v", level.ID, err)
+	}
+
+	prefix := level.ID[:strings.LastIndex(level.ID, "_")]
+	tests := []struct {
+		levelID string
+		want    error
+	}{
+		{prefix + "_zzz", ErrLevelNotIssued},
+		{prefix + "_" + strings.Repeat("1", len(level.ID)-len(prefix)-1), ErrLevelNotIssued},
+		{prefix, ErrInvalidLevelID},
+	}
+	for _, tt := range tests {
+		if _, err := svc.ValidateAnswer(tt.levelID, "s1", "q1", "x"); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateAnswer(%q) error = %v, want %v", tt.levelID, err, tt.want)
+		}
+		if _, err := svc.ValidateAnswer(tt.levelID, "", "q1", "x"); !errors.Is(err, tt.want) {
+			t.Errorf("anonymous ValidateAnswer(%q) error = %v, want %v", tt.levelID, err, tt.want)
+		}
+		if _, err := svc.RegenerateLevel(tt.levelID); !errors.Is(err, tt.want) {
+			t.Errorf("RegenerateLevel(%q) error = %v, want %v", tt.levelID, err, tt.want)
+		}
+	}
+
+	// 同一种子换到其他字根上不能通过校验
+	key, _ := ParseLevelID(level.ID)
+	key.RootID = 2
+	if _, err := svc.GenerateLevelFromKey(key); !errors.Is(err, ErrLevelNotIssued) {
+		t.Errorf("GenerateLevelFromKey(%v) error = %v, want ErrLevelNotIssued", key, err)
+	}
+}
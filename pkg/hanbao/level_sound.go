@@ -0,0 +1,185 @@
+package hanbao
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterLevelGenerator(soundRuleLevel{})
+}
+
+// soundRuleLanguages 读音预言家出题的语言，依次为韩语和日语音读
+var soundRuleLanguages = []string{"ko", "ja"}
+
+// soundRuleFinals 生成干扰项时替换的结尾，按语言
+var soundRuleFinals = map[string][]string{
+	"ko": {"k", "l", "p", "m", "n", "ng", ""},
+	"ja": {"ku", "ki", "tsu", "chi", "n", "i", ""},
+}
+
+// soundRuleLevel 读音预言家：根据中古汉语拟音和读音对应规律，推测汉字在韩语、日语中的读音
+type soundRuleLevel struct{}
+
+// Metadata 关卡类型元数据
+func (soundRuleLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "sound_rule",
+		Aliases:      []string{"predict"},
+		Title:        "读音预言家",
+		Icon:         "🔮",
+		Description:  "根据汉字的古音和读音对应规律，预言它在韩语、日语中的读音，如 學 haewk → 학 hak / がく gaku",
+		TimeLimit:    150,
+		Score:        100,
+		RequiredData: []string{"middle_chinese", "vocabulary:ko", "vocabulary:ja"},
+	}
+}
+
+// Available 字根至少需要在韩语或日语中有一个能对齐的实际读音
+func (soundRuleLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	readings, err := KnownReadings(ctx.Repo, root)
+	if err != nil {
+		return false, err
+	}
+	return len(readings) > 0, nil
+}
+
+// Generate 生成读音预言家关卡，每种语言一道题
+func (g soundRuleLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	readings, err := KnownReadings(ctx.Repo, root)
+	if err != nil {
+		return nil, err
+	}
+
+	var questions []Question
+	for _, language := range soundRuleLanguages {
+		var candidates []KnownReading
+		for _, r := range readings {
+			if r.Language == language {
+				candidates = append(candidates, r)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		known := candidates[ctx.Rand.Intn(len(candidates))]
+
+		question, err := soundRuleQuestion(ctx, root, known, difficulty)
+		if err != nil {
+			return nil, err
+		}
+		question.ID = fmt.Sprintf("q%d", len(questions)+1)
+		questions = append(questions, *question)
+	}
+	if len(questions) == 0 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "ko,ja"})
+	}
+
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("\"%s\"的古音藏着它在韩语和日语中的读音，按规律预言一下", root.Root)
+	level.Questions = questions
+	return level, nil
+}
+
+// soundRuleQuestion 根据字根的一个实际读音出题，干扰项是不符合规律的读音
+func soundRuleQuestion(ctx *LevelContext, root *CharacterRoot, known KnownReading, difficulty int) (*Question, error) {
+	prediction, ok := PredictReading(root, known.Language)
+	if !ok {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "middle_chinese"})
+	}
+	score, ok := ScoreReading(root, known.Language, known.Reading)
+	if !ok {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "middle_chinese"})
+	}
+
+	distractors, err := soundRuleDistractors(ctx, root, known)
+	if err != nil {
+		return nil, err
+	}
+
+	source := fmt.Sprintf("中古汉语拟音是 %s", root.MiddleChinese)
+	if prediction.Source == PredictionFromPinyin {
+		source = fmt.Sprintf("普通话读 %s", root.Pinyin)
+	}
+	content := fmt.Sprintf("「%s」的%s。按照读音对应规律，它在%s中最可能读作？", root.Root, source, soundRuleLanguageName(known.Language))
+	// 韩语词用谚文书写就是读音本身，出处改用汉字写法，没有汉字写法时不写出处
+	word := known.Word
+	if known.Language == "ko" {
+		word = known.Hanja
+	}
+	if DifficultyShowsReading(difficulty) && word != "" {
+		content += fmt.Sprintf("（出自 %s）", word)
+	}
+
+	return &Question{
+		Type:          "multiple_choice",
+		Content:       content,
+		Options:       ctx.Options(known.Reading, distractors, difficulty),
+		CorrectAnswer: known.Reading,
+		Hint:          ctx.Hint(difficulty, soundRuleHint(prediction)),
+		Explanation:   score.Explanation,
+		VocabularyIDs: []int64{known.VocabularyID},
+		SoundRules:    soundRuleIDs(prediction.Rules),
+		RuleLanguage:  known.Language,
+	}, nil
+}
+
+// soundRuleDistractors 不符合规律的读音：先把实际读音换成其他韵尾，再加上其他字根的读音
+func soundRuleDistractors(ctx *LevelContext, root *CharacterRoot, known KnownReading) ([]Distractor, error) {
+	finals := soundRuleFinals[known.Language]
+	stem := known.Reading
+	for _, final := range finals {
+		if final != "" && readingHasFinal(known.Reading, final) && len(final) < len(stem) {
+			stem = strings.TrimSuffix(known.Reading, final)
+			break
+		}
+	}
+
+	var wrongFinal, otherRoot []Distractor
+	for _, final := range finals {
+		variant := stem + final
+		if score, ok := ScoreReading(root, known.Language, variant); ok && score.Score < 1 {
+			wrongFinal = append(wrongFinal, Distractor{Text: variant, Source: DistractorWrongFinal})
+		}
+	}
+
+	roots, err := ctx.Repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+	for i := range roots {
+		if roots[i].ID == root.ID {
+			continue
+		}
+		readings, err := KnownReadings(ctx.Repo, &roots[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range readings {
+			if r.Language != known.Language {
+				continue
+			}
+			if score, ok := ScoreReading(root, known.Language, r.Reading); ok && score.Score < 1 {
+				otherRoot = append(otherRoot, Distractor{Text: r.Reading, Source: DistractorOtherRoot})
+			}
+		}
+	}
+	return dedupeDistractors(append(wrongFinal, otherRoot...), known.Reading), nil
+}
+
+// soundRuleHint 提示推测的读音形式
+func soundRuleHint(prediction *ReadingPrediction) string {
+	hint := fmt.Sprintf("按规律，读音的形式应该是 %s", prediction.Pattern)
+	if strings.Contains(prediction.Pattern, "∅") {
+		hint += "（∅ 表示以元音开头或结尾）"
+	}
+	return hint
+}
+
+// soundRuleLanguageName 出题时的语言名称，日语特别指明是音读
+func soundRuleLanguageName(language string) string {
+	if language == "ja" {
+		return "日语音读"
+	}
+	return LanguageName(language)
+}
@@ -0,0 +1,245 @@
+package hanbao
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/hash"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+const levelCacheKeyPrefix = "hanbao:level:"
+
+// LevelInstance 某个会话挑战的关卡，保存标准答案和答题记录
+type LevelInstance struct {
+	Level     Level                   `json:"level"`
+	SessionID string                  `json:"session_id,omitempty"` // 答题的会话，匿名答题时为空
+	Answers   map[string]AnswerRecord `json:"answers"`              // 按问题ID记录的答题结果
+	CreatedAt time.Time               `json:"created_at"`           // 第一次答题的时间
+}
+
+// AnswerRecord 单个问题的答题记录
+type AnswerRecord struct {
+	Answer     string    `json:"answer"`
+	Correct    bool      `json:"correct"`
+	Score      int       `json:"score"`
+	AnsweredAt time.Time `json:"answered_at"`
+}
+
+// Completed 判断关卡的所有问题是否都已作答
+func (i *LevelInstance) Completed() bool {
+	return len(i.Answers) >= len(i.Level.Questions)
+}
+
+// LevelStore 关卡实例存储，按关卡ID和会话ID保存
+type LevelStore interface {
+	// Get 获取会话的关卡实例，不存在时返回 ErrLevelNotFound
+	Get(levelID, sessionID string) (*LevelInstance, error)
+	// RecordAnswer 原子地记录一道题的答题结果，关卡实例不存在时按 instance 创建（instance 中的答题记录不会保存）；
+	// 问题已经作答时返回 ErrAnswerAlreadySubmitted，多个实例共享存储时同样只有一次提交成功。
+	// 返回记录后已作答的问题数
+	RecordAnswer(instance *LevelInstance, questionID string, record AnswerRecord) (int, error)
+}
+
+// levelInstanceKey 关卡实例的存储键
+func levelInstanceKey(levelID, sessionID string) string {
+	if sessionID == "" {
+		return levelID
+	}
+	return levelID + "@" + sessionID
+}
+
+// levelInstanceExpire 关卡实例的剩余有效期，从第一次答题时开始计算
+func levelInstanceExpire(instance *LevelInstance, expire time.Duration) (time.Duration, error) {
+	remaining := expire - time.Since(instance.CreatedAt)
+	if remaining <= 0 {
+		return 0, ErrLevelNotFound
+	}
+	return remaining, nil
+}
+
+// MemoryLevelStore 基于内存的关卡存储，过期的答题记录自动清理
+type MemoryLevelStore struct {
+	mu     sync.Mutex // 保证检查是否已作答和写入答题记录是原子的
+	levels *collection.Cache
+	expire time.Duration
+}
+
+// NewMemoryLevelStore 创建内存关卡存储
+func NewMemoryLevelStore(expire time.Duration) (*MemoryLevelStore, error) {
+	levels, err := collection.NewCache(expire, collection.WithName("hanbao-level"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemoryLevelStore{
+		levels: levels,
+		expire: expire,
+	}, nil
+}
+
+// Get 获取关卡实例
+func (s *MemoryLevelStore) Get(levelID, sessionID string) (*LevelInstance, error) {
+	val, ok := s.levels.Get(levelInstanceKey(levelID, sessionID))
+	if !ok {
+		return nil, ErrLevelNotFound
+	}
+
+	return cloneLevelInstance(val.(*LevelInstance)), nil
+}
+
+// RecordAnswer 记录答题结果，过期时间从第一次答题时开始计算
+func (s *MemoryLevelStore) RecordAnswer(instance *LevelInstance, questionID string, record AnswerRecord) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := levelInstanceKey(instance.Level.ID, instance.SessionID)
+	stored := cloneLevelInstance(instance)
+	stored.Answers = make(map[string]AnswerRecord)
+	if val, ok := s.levels.Get(key); ok {
+		stored = cloneLevelInstance(val.(*LevelInstance))
+	}
+	if _, answered := stored.Answers[questionID]; answered {
+		return 0, ErrAnswerAlreadySubmitted
+	}
+
+	expire, err := levelInstanceExpire(stored, s.expire)
+	if err != nil {
+		return 0, err
+	}
+	stored.Answers[questionID] = record
+	s.levels.SetWithExpire(key, stored, expire)
+	return len(stored.Answers), nil
+}
+
+// Redis 中每个关卡实例是一个哈希：levelInstanceField 保存不含答题记录的关卡实例，
+// 每道题的答题记录保存在 levelAnswerFieldPrefix+问题ID 中，用 HSETNX 保证同一问题只记录一次
+const (
+	levelInstanceField     = "instance"
+	levelAnswerFieldPrefix = "answer:"
+)
+
+// recordAnswerScript 第一次答题时创建关卡实例并设置过期时间，问题已作答时返回 -1，否则返回已作答的问题数
+const recordAnswerScript = `
+if redis.call("HSETNX", KEYS[1], ARGV[1], ARGV[2]) == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+end
+if redis.call("HSETNX", KEYS[1], ARGV[4], ARGV[5]) == 0 then
+	return -1
+end
+return redis.call("HLEN", KEYS[1]) - 1
+`
+
+// RedisLevelStore 基于Redis的关卡存储，关卡实例按键分布到 Cache 配置的各个节点
+type RedisLevelStore struct {
+	nodes  *hash.ConsistentHash
+	expire time.Duration
+}
+
+// NewRedisLevelStore 创建Redis关卡存储，使用配置中的 Cache 集群
+func NewRedisLevelStore(c cache.CacheConf, expire time.Duration) (*RedisLevelStore, error) {
+	if len(c) == 0 || cache.TotalWeights(c) <= 0 {
+		return nil, errors.New("Redis关卡存储需要配置 Cache 节点")
+	}
+
+	nodes := hash.NewConsistentHash()
+	for _, node := range c {
+		rds, err := redis.NewRedis(node.RedisConf)
+		if err != nil {
+			return nil, err
+		}
+		nodes.AddWithWeight(rds, node.Weight)
+	}
+	return &RedisLevelStore{
+		nodes:  nodes,
+		expire: expire,
+	}, nil
+}
+
+// node 存储键所在的Redis节点
+func (s *RedisLevelStore) node(key string) *redis.Redis {
+	node, _ := s.nodes.Get(key)
+	return node.(*redis.Redis)
+}
+
+// Get 获取关卡实例
+func (s *RedisLevelStore) Get(levelID, sessionID string) (*LevelInstance, error) {
+	key := levelCacheKeyPrefix + levelInstanceKey(levelID, sessionID)
+	fields, err := s.node(key).Hgetall(key)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := fields[levelInstanceField]
+	if !ok {
+		return nil, ErrLevelNotFound
+	}
+
+	var instance LevelInstance
+	if err := json.Unmarshal([]byte(data), &instance); err != nil {
+		return nil, err
+	}
+	instance.Answers = make(map[string]AnswerRecord)
+	for field, value := range fields {
+		questionID, ok := strings.CutPrefix(field, levelAnswerFieldPrefix)
+		if !ok {
+			continue
+		}
+		var record AnswerRecord
+		if err := json.Unmarshal([]byte(value), &record); err != nil {
+			return nil, err
+		}
+		instance.Answers[questionID] = record
+	}
+	return &instance, nil
+}
+
+// RecordAnswer 记录答题结果，过期时间从第一次答题时开始计算
+func (s *RedisLevelStore) RecordAnswer(instance *LevelInstance, questionID string, record AnswerRecord) (int, error) {
+	expire, err := levelInstanceExpire(instance, s.expire)
+	if err != nil {
+		return 0, err
+	}
+
+	header := *instance
+	header.Answers = nil
+	instanceData, err := json.Marshal(header)
+	if err != nil {
+		return 0, err
+	}
+	recordData, err := json.Marshal(record)
+	if err != nil {
+		return 0, err
+	}
+
+	key := levelCacheKeyPrefix + levelInstanceKey(instance.Level.ID, instance.SessionID)
+	val, err := s.node(key).Eval(recordAnswerScript, []string{key},
+		levelInstanceField, string(instanceData), expire.Milliseconds(),
+		levelAnswerFieldPrefix+questionID, string(recordData))
+	if err != nil {
+		return 0, err
+	}
+	answered, ok := val.(int64)
+	if !ok {
+		return 0, fmt.Errorf("记录答题结果返回了意外的结果: %v", val)
+	}
+	if answered < 0 {
+		return 0, ErrAnswerAlreadySubmitted
+	}
+	return int(answered), nil
+}
+
+// cloneLevelInstance 复制关卡实例，避免调用方修改存储中的数据
+func cloneLevelInstance(instance *LevelInstance) *LevelInstance {
+	clone := *instance
+	clone.Answers = make(map[string]AnswerRecord, len(instance.Answers))
+	for questionID, record := range instance.Answers {
+		clone.Answers[questionID] = record
+	}
+	return &clone
+}
@@ -0,0 +1,75 @@
+package hanbao
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+func TestLevelStoreRecordAnswerOnce(t *testing.T) {
+	mr := miniredis.RunT(t)
+	redisStore, err := NewRedisLevelStore(cache.CacheConf{{RedisConf: redis.RedisConf{Host: mr.Addr(), Type: redis.NodeType}, Weight: 100}}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	memoryStore, err := NewMemoryLevelStore(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, store := range map[string]LevelStore{"memory": memoryStore, "redis": redisStore} {
+		t.Run(name, func(t *testing.T) {
+			instance := &LevelInstance{
+				Level:     Level{ID: "pron_1_1_abc", Questions: []Question{{ID: "q1"}, {ID: "q2"}}},
+				SessionID: "s1",
+				Answers:   make(map[string]AnswerRecord),
+				CreatedAt: time.Now(),
+			}
+
+			// 并发提交同一问题，只有一次成功
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			succeeded := 0
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := store.RecordAnswer(instance, "q1", AnswerRecord{Answer: "a", Correct: true, Score: 10})
+					switch {
+					case err == nil:
+						mu.Lock()
+						succeeded++
+						mu.Unlock()
+					case !errors.Is(err, ErrAnswerAlreadySubmitted):
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+			if succeeded != 1 {
+				t.Fatalf("%d submissions succeeded, want 1", succeeded)
+			}
+
+			answered, err := store.RecordAnswer(instance, "q2", AnswerRecord{Answer: "b"})
+			if err != nil || answered != 2 {
+				t.Fatalf("RecordAnswer(q2) = %d, %v, want 2, nil", answered, err)
+			}
+
+			stored, err := store.Get(instance.Level.ID, instance.SessionID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !stored.Completed() || stored.Answers["q1"].Score != 10 || len(stored.Level.Questions) != 2 {
+				t.Errorf("Get() = %+v", stored)
+			}
+			if _, err := store.Get(instance.Level.ID, "s2"); !errors.Is(err, ErrLevelNotFound) {
+				t.Errorf("Get(other session) error = %v, want ErrLevelNotFound", err)
+			}
+		})
+	}
+}
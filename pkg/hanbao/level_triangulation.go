@@ -0,0 +1,217 @@
+package hanbao
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterLevelGenerator(triangulationLevel{})
+}
+
+// triangulationLanguages 三语连连看的连线列，依次为日语和韩语
+var triangulationLanguages = []struct {
+	Language string
+	Label    string
+}{
+	{"ja", "日语"},
+	{"ko", "韩语"},
+}
+
+// minTriangulationRows 连线网格至少需要的行数
+const minTriangulationRows = 3
+
+// triangulationLevel 三语连连看：把中文词和它的日语、韩语形式连起来，每条连线单独计分
+type triangulationLevel struct{}
+
+// Metadata 关卡类型元数据
+func (triangulationLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "triangulation",
+		Aliases:      []string{"tri"},
+		Title:        "三语连连看",
+		Icon:         "🔺",
+		Description:  "把中文词和它的日语、韩语形式连起来，发现三种语言之间的读音对应",
+		TimeLimit:    240,
+		Score:        180,
+		RequiredData: []string{"vocabulary:ja", "vocabulary:ko", "chinese"},
+	}
+}
+
+// Available 字根至少需要1组中日韩同源词，且全部同源词足够组成连线网格
+func (triangulationLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	sets, err := triangulationSets(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(sets) < minTriangulationRows {
+		return false, nil
+	}
+	for _, set := range sets {
+		if set.HasRoot(root.ID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Generate 生成三语连连看关卡，包含字根的同源词优先，不够时用其他字根的同源词补足
+func (g triangulationLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	sets, err := triangulationSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rootSets, otherSets []CognateSet
+	for _, set := range sets {
+		if set.HasRoot(root.ID) {
+			rootSets = append(rootSets, set)
+		} else {
+			otherSets = append(otherSets, set)
+		}
+	}
+	if len(rootSets) == 0 || len(sets) < minTriangulationRows {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "ja,ko"})
+	}
+
+	// 难度1-2连3行，3-4连4行，5连5行
+	count := min(minTriangulationRows+(normalizeDifficulty(difficulty)-1)/2, len(sets))
+	var selected []CognateSet
+	for _, group := range [][]CognateSet{rootSets, otherSets} {
+		for _, i := range ctx.Rand.Perm(len(group)) {
+			if len(selected) < count {
+				selected = append(selected, group[i])
+			}
+		}
+	}
+	ctx.Rand.Shuffle(len(selected), func(i, j int) { selected[i], selected[j] = selected[j], selected[i] })
+
+	grid := &MatchingGrid{Rows: make([]string, len(selected))}
+	answer := make([][]string, len(selected))
+	explanations := make([]string, len(selected))
+	var vocabularyIDs []int64
+	for i, set := range selected {
+		grid.Rows[i] = set.Chinese
+		answer[i] = []string{set.Chinese}
+		for _, l := range triangulationLanguages {
+			answer[i] = append(answer[i], set.Words[l.Language].Word)
+		}
+		explanations[i] = cognateExplanation(set)
+		vocabularyIDs = append(vocabularyIDs, set.VocabularyIDs("ja", "ko")...)
+	}
+
+	for c, l := range triangulationLanguages {
+		column := MatchingColumn{Label: l.Label}
+		for _, row := range answer {
+			column.Options = append(column.Options, row[c+1])
+		}
+		// 高难度时每列多一个同字根但不同源的干扰项，如 電車 / 전철
+		if !DifficultyShowsReading(difficulty) {
+			decoy, err := triangulationDecoy(ctx, root, l.Language, column.Options)
+			if err != nil {
+				return nil, err
+			}
+			if decoy != "" {
+				column.Options = append(column.Options, decoy)
+			}
+		}
+		ctx.Rand.Shuffle(len(column.Options), func(i, j int) {
+			column.Options[i], column.Options[j] = column.Options[j], column.Options[i]
+		})
+		grid.Columns = append(grid.Columns, column)
+	}
+
+	example := selected[0]
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("同一个汉字词在中日韩三种语言中连成一线，从\"%s\"出发", root.Root)
+	level.Questions = []Question{
+		{
+			ID:            "q1",
+			Type:          QuestionTypeMatching,
+			Content:       "把每个中文词和它的日语、韩语形式连起来，每行填写 中文=日语=韩语，行之间用分号分隔",
+			Matching:      grid,
+			CorrectAnswer: FormatMatchingAnswer(answer),
+			Hint: ctx.Hint(difficulty, fmt.Sprintf("日语和韩语的汉字词都保留了古汉语的读音，比如「%s」在日语读 %s，在韩语读 %s",
+				example.Chinese, romanized(example.Words["ja"]), example.Words["ko"].Pronunciation)),
+			Explanation:   strings.Join(explanations, "\n"),
+			VocabularyIDs: vocabularyIDs,
+		},
+	}
+
+	return level, nil
+}
+
+// triangulationSets 所有同时有日语和韩语形式的同源词组
+func triangulationSets(ctx *LevelContext) ([]CognateSet, error) {
+	vocabs, err := ctx.Repo.ListVocabularies()
+	if err != nil {
+		return nil, err
+	}
+	return CognateSets(vocabs, "ja", "ko"), nil
+}
+
+// triangulationDecoy 字根在指定语言中不属于网格的词汇，用作多余的干扰项，没有时返回空字符串
+func triangulationDecoy(ctx *LevelContext, root *CharacterRoot, language string, used []string) (string, error) {
+	vocabs, err := ctx.VocabulariesByLanguage(root.ID, language)
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []string
+	for _, vocab := range vocabs {
+		if !containsString(used, vocab.Word) {
+			candidates = append(candidates, vocab.Word)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	return candidates[ctx.Rand.Intn(len(candidates))], nil
+}
+
+// hangulFinalNotes 谚文收音对应的古汉语韵尾在三种语言中的演变，键为收音在音节编码中的序号
+var hangulFinalNotes = map[int]string{
+	1:  "的收音 -k 是古汉语的入声韵尾，日语读作 -ku/-ki，普通话已经脱落",     // ㄱ
+	4:  "的收音 -n 在三种语言中都保留了下来",                       // ㄴ
+	8:  "的收音 -l 对应古汉语的入声 -t，日语读作 -tsu/-chi，普通话已经脱落", // ㄹ
+	16: "的收音 -m 保留了古汉语的 -m，日语和普通话都变成了 -n",           // ㅁ
+	17: "的收音 -p 是古汉语的入声韵尾，日语读作 -u（古时为 -fu），普通话已经脱落", // ㅂ
+	21: "的收音 -ng 在普通话中保留，日语变成长音 -i/-u，如 sei、kou",    // ㅇ
+}
+
+// cognateExplanation 同源词组的读音对照，并根据韩语的收音说明古汉语韵尾在三种语言中的演变
+func cognateExplanation(set CognateSet) string {
+	ja, ko := set.Words["ja"], set.Words["ko"]
+	explanation := fmt.Sprintf("%s = %s（%s）= %s（%s）", set.Chinese, ja.Word, romanized(ja), ko.Word, ko.Pronunciation)
+
+	var finals []int
+	syllables := make(map[int][]string)
+	for _, s := range ko.HanjaSyllables() {
+		final := hangulFinal(s.Hangul)
+		if _, ok := hangulFinalNotes[final]; !ok {
+			continue
+		}
+		if _, ok := syllables[final]; !ok {
+			finals = append(finals, final)
+		}
+		syllables[final] = append(syllables[final], fmt.Sprintf("%s（%s）", s.Hangul, s.Hanja))
+	}
+
+	notes := make([]string, len(finals))
+	for i, final := range finals {
+		notes[i] = strings.Join(syllables[final], "、") + hangulFinalNotes[final]
+	}
+	if len(notes) > 0 {
+		explanation += "：" + strings.Join(notes, "；")
+	}
+	return explanation
+}
+
+// hangulFinal 谚文音节的收音序号，0 表示开音节，不是谚文音节时返回 -1
+func hangulFinal(syllable string) int {
+	r := []rune(syllable)[0]
+	if r < 0xAC00 || r > 0xD7A3 {
+		return -1
+	}
+	return int(r-0xAC00) % 28
+}
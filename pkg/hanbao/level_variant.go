@@ -0,0 +1,126 @@
+package hanbao
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterLevelGenerator(variantLevel{})
+}
+
+// variantQuestionRegions 字形辨认出题的地区，依次为日本、韩国、台湾和香港
+var variantQuestionRegions = []struct {
+	Region string
+	Place  string
+}{
+	{VariantJapanese, "日本"},
+	{VariantKorean, "韩国"},
+	{VariantTraditional, "台湾和香港"},
+}
+
+// variantLevel 字形辨认：同一个字在简体、繁体、日本新字体和韩国汉字中的写法，选出指定地区的字形
+type variantLevel struct{}
+
+// Metadata 关卡类型元数据
+func (variantLevel) Metadata() LevelTypeMetadata {
+	return LevelTypeMetadata{
+		Type:         "variant",
+		Aliases:      []string{"glyph"},
+		Title:        "字形辨认",
+		Icon:         "🀄",
+		Description:  "同一个字在各地写法不同，选出日本、韩国或台湾香港的字形，如 发 → 日本 発、韩国 發",
+		TimeLimit:    90,
+		Score:        60,
+		RequiredData: []string{"variants"},
+	}
+}
+
+// Available 字根至少需要两种不同的写法
+func (variantLevel) Available(ctx *LevelContext, root *CharacterRoot) (bool, error) {
+	return len(root.Forms()) > 1, nil
+}
+
+// Generate 生成字形辨认关卡，每个地区一道题，写法与简体或前面的题相同的地区不再出题
+func (g variantLevel) Generate(ctx *LevelContext, root *CharacterRoot, difficulty int) (*Level, error) {
+	forms := root.Forms()
+	if len(forms) < 2 {
+		return nil, ErrInsufficientVocabulary.WithDetails(map[string]any{"root": root.Root, "language": "variants"})
+	}
+
+	var questions []Question
+	asked := map[string]bool{root.Root: true} // 与简体相同的写法不出题，否则题目本身就给出了答案
+	for _, region := range variantQuestionRegions {
+		correct := root.Form(region.Region)
+		if asked[correct] {
+			continue
+		}
+		asked[correct] = true
+
+		distractors, err := ctx.Distractors().VariantDistractors(*root, region.Region)
+		if err != nil {
+			return nil, err
+		}
+		explanation, err := variantExplanation(ctx, root, region.Region, region.Place)
+		if err != nil {
+			return nil, err
+		}
+
+		questions = append(questions, Question{
+			ID:            fmt.Sprintf("q%d", len(questions)+1),
+			Type:          "multiple_choice",
+			Content:       fmt.Sprintf("简体字「%s」在%s写作？", root.Root, region.Place),
+			Options:       ctx.Options(correct, distractors, difficulty),
+			CorrectAnswer: correct,
+			Hint:          ctx.Hint(difficulty, variantHint(region.Region)),
+			Explanation:   explanation,
+		})
+	}
+
+	level := ctx.NewLevel(g.Metadata(), root, difficulty)
+	level.Description = fmt.Sprintf("\"%s\"在各地有%d种写法，认一认", root.Root, len(forms))
+	level.Questions = questions
+	return level, nil
+}
+
+// variantExplanation 字根在某个地区的写法，日本、韩国的写法举出用该字形书写的日语、韩语词汇；
+// 只写出本题问到的字形，其他地区的写法是后面题目的答案
+func variantExplanation(ctx *LevelContext, root *CharacterRoot, region, place string) (string, error) {
+	form := root.Form(region)
+	explanation := fmt.Sprintf("「%s」在%s写作 %s", root.Root, place, form)
+
+	language := ""
+	switch region {
+	case VariantJapanese:
+		language = "ja"
+	case VariantKorean:
+		language = "ko"
+	default:
+		return explanation, nil
+	}
+	vocabs, err := ctx.VocabulariesByLanguage(root.ID, language)
+	if err != nil {
+		return "", err
+	}
+	for _, vocab := range vocabs {
+		if language == "ja" && strings.Contains(vocab.Word, form) {
+			return explanation + fmt.Sprintf("，如日语词 %s", vocab.Word), nil
+		}
+		if language == "ko" && strings.Contains(vocab.Hanja, form) {
+			return explanation + fmt.Sprintf("，如韩语词 %s 写作 %s", vocab.Word, vocab.Hanja), nil
+		}
+	}
+	return explanation, nil
+}
+
+// variantHint 各地区写法的特点
+func variantHint(region string) string {
+	switch region {
+	case VariantJapanese:
+		return "日本新字体也简化了一些繁体字，但简化方式常常和大陆不同"
+	case VariantKorean:
+		return "韩国汉字基本沿用传统的繁体写法"
+	default:
+		return "台湾和香港使用传统的繁体字"
+	}
+}
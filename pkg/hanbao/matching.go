@@ -0,0 +1,92 @@
+package hanbao
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuestionTypeMatching 连线题，答案格式见 MatchingGrid
+const QuestionTypeMatching = "matching"
+
+// 连线题答案的分隔符：行之间用分号，同一行的各项用等号，如 电话=電話=전화;学生=学生=학생
+const (
+	matchingRowSeparator  = ";"
+	matchingCellSeparator = "="
+)
+
+// MatchingGrid 连线题的网格：每一行的起点需要与每一列中的一项连线，
+// 答案逐行填写 起点=第1列=第2列…，行的顺序不限，每条连线单独计分
+type MatchingGrid struct {
+	Rows    []string         `json:"rows"`    // 每行的起点，如中文词
+	Columns []MatchingColumn `json:"columns"` // 需要连线的各列
+}
+
+// MatchingColumn 连线题的一列
+type MatchingColumn struct {
+	Label   string   `json:"label"`   // 列名，如 "日语"
+	Options []string `json:"options"` // 可选项，顺序已打乱，难度高时含有多余的干扰项
+}
+
+// FormatMatchingAnswer 把每行的连线格式化为连线题的答案
+func FormatMatchingAnswer(rows [][]string) string {
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = strings.Join(row, matchingCellSeparator)
+	}
+	return strings.Join(lines, matchingRowSeparator)
+}
+
+// parseMatchingAnswer 解析连线题的答案，按起点索引每行的连线；换行也可以作为行分隔符
+func parseMatchingAnswer(answer string) map[string][]string {
+	rows := make(map[string][]string)
+	answer = strings.ReplaceAll(answer, "\n", matchingRowSeparator)
+	for _, line := range strings.Split(answer, matchingRowSeparator) {
+		cells := strings.Split(line, matchingCellSeparator)
+		for i := range cells {
+			cells[i] = normalizeAnswer(cells[i])
+		}
+		if cells[0] != "" {
+			rows[cells[0]] = cells[1:]
+		}
+	}
+	return rows
+}
+
+// checkMatchingAnswer 逐条比对连线，按连对的比例给分，全部连对才算答对
+func checkMatchingAnswer(question *Question, userAnswer string) *AnswerResult {
+	expected := parseMatchingAnswer(question.CorrectAnswer)
+	actual := parseMatchingAnswer(userAnswer)
+
+	correct, total := 0, 0
+	for start, links := range expected {
+		got := actual[start]
+		for i, link := range links {
+			total++
+			if i < len(got) && got[i] == link {
+				correct++
+			}
+		}
+	}
+
+	result := &AnswerResult{
+		Correct:       total > 0 && correct == total,
+		CorrectAnswer: question.CorrectAnswer,
+		Explanation:   question.Explanation,
+		VocabularyIDs: question.VocabularyIDs,
+	}
+	if total > 0 {
+		result.Score = question.Score * correct / total
+	}
+	switch {
+	case result.Correct:
+		result.NextHint = "全部连对！继续探索更多汉字词根的奥秘"
+	case correct > 0:
+		result.NextHint = fmt.Sprintf("连对了 %d/%d 条", correct, total)
+		if question.Hint != "" {
+			result.NextHint += "，" + question.Hint
+		}
+	default:
+		result.NextHint = question.Hint
+	}
+	return result
+}
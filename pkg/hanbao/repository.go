@@ -0,0 +1,169 @@
+package hanbao
+
+import (
+	"sync"
+)
+
+// ContentRepository 内容仓库，提供字根、词汇和方言示例的读取
+type ContentRepository interface {
+	// ListRoots 获取所有字根
+	ListRoots() ([]CharacterRoot, error)
+	// GetRootByID 根据ID获取字根，不存在时返回 ErrContentNotFound
+	GetRootByID(rootID int64) (*CharacterRoot, error)
+	// GetRootByChar 根据字根汉字的任一写法获取字根，不存在时返回 ErrContentNotFound
+	GetRootByChar(char string) (*CharacterRoot, error)
+
+	// ListVocabularies 获取所有词汇
+	ListVocabularies() ([]Vocabulary, error)
+	// GetVocabularyByID 根据ID获取词汇，不存在时返回 ErrContentNotFound
+	GetVocabularyByID(vocabularyID int64) (*Vocabulary, error)
+	// GetVocabulariesByRoot 获取包含指定字根的所有词汇（含复合词）
+	GetVocabulariesByRoot(rootID int64) ([]Vocabulary, error)
+	// GetVocabulariesByLanguage 获取指定语言的所有词汇
+	GetVocabulariesByLanguage(language string) ([]Vocabulary, error)
+
+	// ListDialectExamples 获取所有方言示例
+	ListDialectExamples() ([]DialectExample, error)
+	// GetDialectExamplesByRoot 获取指定字根的方言示例
+	GetDialectExamplesByRoot(rootID int64) ([]DialectExample, error)
+}
+
+// MemoryContentRepository 基于内存的内容仓库
+type MemoryContentRepository struct {
+	mu              sync.RWMutex
+	roots           []CharacterRoot
+	vocabularies    []Vocabulary
+	dialectExamples []DialectExample
+}
+
+// NewMemoryContentRepository 创建内存内容仓库
+func NewMemoryContentRepository(roots []CharacterRoot, vocabularies []Vocabulary, dialectExamples []DialectExample) *MemoryContentRepository {
+	repo := &MemoryContentRepository{}
+	repo.Replace(roots, vocabularies, dialectExamples)
+	return repo
+}
+
+// NewDefaultContentRepository 创建使用内置数据的内存内容仓库
+func NewDefaultContentRepository() *MemoryContentRepository {
+	return NewMemoryContentRepository(CharacterRootsData, VocabularyData, DialectExamplesData)
+}
+
+// Replace 整体替换仓库内容，用于不重启服务的内容更新
+func (r *MemoryContentRepository) Replace(roots []CharacterRoot, vocabularies []Vocabulary, dialectExamples []DialectExample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roots = append([]CharacterRoot(nil), roots...)
+	r.vocabularies = append([]Vocabulary(nil), vocabularies...)
+	r.dialectExamples = append([]DialectExample(nil), dialectExamples...)
+}
+
+// ListRoots 获取所有字根
+func (r *MemoryContentRepository) ListRoots() ([]CharacterRoot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]CharacterRoot(nil), r.roots...), nil
+}
+
+// GetRootByID 根据ID获取字根
+func (r *MemoryContentRepository) GetRootByID(rootID int64) (*CharacterRoot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, root := range r.roots {
+		if root.ID == rootID {
+			return &root, nil
+		}
+	}
+	return nil, ErrContentNotFound
+}
+
+// GetRootByChar 根据字根汉字获取字根，繁体字、日本新字体和韩国汉字也能匹配，简体字优先
+func (r *MemoryContentRepository) GetRootByChar(char string) (*CharacterRoot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, root := range r.roots {
+		if root.Root == char {
+			return &root, nil
+		}
+	}
+	for _, root := range r.roots {
+		if root.HasForm(char) {
+			return &root, nil
+		}
+	}
+	return nil, ErrContentNotFound
+}
+
+// ListVocabularies 获取所有词汇
+func (r *MemoryContentRepository) ListVocabularies() ([]Vocabulary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Vocabulary(nil), r.vocabularies...), nil
+}
+
+// GetVocabularyByID 根据ID获取词汇
+func (r *MemoryContentRepository) GetVocabularyByID(vocabularyID int64) (*Vocabulary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, vocab := range r.vocabularies {
+		if vocab.ID == vocabularyID {
+			return &vocab, nil
+		}
+	}
+	return nil, ErrContentNotFound
+}
+
+// GetVocabulariesByRoot 获取指定字根的所有词汇
+func (r *MemoryContentRepository) GetVocabulariesByRoot(rootID int64) ([]Vocabulary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []Vocabulary
+	for _, vocab := range r.vocabularies {
+		if vocab.HasRoot(rootID) {
+			result = append(result, vocab)
+		}
+	}
+	return result, nil
+}
+
+// GetVocabulariesByLanguage 获取指定语言的所有词汇
+func (r *MemoryContentRepository) GetVocabulariesByLanguage(language string) ([]Vocabulary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []Vocabulary
+	for _, vocab := range r.vocabularies {
+		if vocab.Language == language {
+			result = append(result, vocab)
+		}
+	}
+	return result, nil
+}
+
+// ListDialectExamples 获取所有方言示例
+func (r *MemoryContentRepository) ListDialectExamples() ([]DialectExample, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]DialectExample(nil), r.dialectExamples...), nil
+}
+
+// GetDialectExamplesByRoot 获取指定字根的方言示例
+func (r *MemoryContentRepository) GetDialectExamplesByRoot(rootID int64) ([]DialectExample, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []DialectExample
+	for _, example := range r.dialectExamples {
+		if example.RootID == rootID {
+			result = append(result, example)
+		}
+	}
+	return result, nil
+}
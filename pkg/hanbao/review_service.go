@@ -0,0 +1,191 @@
+package hanbao
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DueReview 待复习的词汇
+type DueReview struct {
+	Card       ReviewCard `json:"card"`
+	Vocabulary Vocabulary `json:"vocabulary"`
+}
+
+// ReviewForecast 某一天到期的复习数量
+type ReviewForecast struct {
+	Date  string `json:"date"` // 日期，格式 2006-01-02
+	Count int    `json:"count"`
+}
+
+// ReviewService 间隔重复复习服务，为学习者解锁的词汇安排复习
+type ReviewService struct {
+	repo      ContentRepository
+	store     ReviewStore
+	scheduler *FSRS
+	mu        sync.Mutex // 保护同一进程内对卡片的读-改-写
+}
+
+// NewReviewService 创建复习服务
+func NewReviewService(repo ContentRepository, store ReviewStore, scheduler *FSRS) *ReviewService {
+	return &ReviewService{
+		repo:      repo,
+		store:     store,
+		scheduler: scheduler,
+	}
+}
+
+// EnrollUnlocked 为组成字根都已解锁的词汇创建新卡片，已有卡片保持不变，返回新建的数量
+func (s *ReviewService) EnrollUnlocked(userID string, unlockedRoots []int64) (int, error) {
+	unlocked := make(map[int64]bool, len(unlockedRoots))
+	for _, rootID := range unlockedRoots {
+		unlocked[rootID] = true
+	}
+
+	vocabs, err := s.repo.ListVocabularies()
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	enrolled := 0
+	for _, vocab := range vocabs {
+		if !vocab.UnlockedBy(unlocked) {
+			continue
+		}
+
+		_, err := s.store.Get(userID, vocab.ID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrReviewCardNotFound) {
+			return enrolled, err
+		}
+
+		if err := s.store.Save(newReviewCard(userID, vocab.ID, now)); err != nil {
+			return enrolled, err
+		}
+		enrolled++
+	}
+
+	return enrolled, nil
+}
+
+// DueReviews 获取已到期的复习，按到期时间排序
+func (s *ReviewService) DueReviews(userID string, now time.Time, limit int) ([]DueReview, error) {
+	cards, err := s.store.ListDue(userID, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DueReview, 0, len(cards))
+	for _, card := range cards {
+		vocab, err := s.repo.GetVocabularyByID(card.VocabularyID)
+		if errors.Is(err, ErrContentNotFound) {
+			continue // 词汇已从内容中移除
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, DueReview{Card: card, Vocabulary: *vocab})
+	}
+
+	return result, nil
+}
+
+// Grade 提交一次复习评分，词汇还没有卡片时作为新卡片处理
+func (s *ReviewService) Grade(userID string, vocabularyID int64, grade ReviewGrade, now time.Time) (*ReviewCard, error) {
+	if !grade.Valid() {
+		return nil, ErrInvalidReviewGrade.WithDetails(map[string]any{"grade": int(grade)})
+	}
+
+	_, err := s.repo.GetVocabularyByID(vocabularyID)
+	if errors.Is(err, ErrContentNotFound) {
+		return nil, ErrVocabularyNotFound.WithDetails(map[string]any{"vocabulary_id": vocabularyID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grade(userID, vocabularyID, grade, now)
+}
+
+// RecordAnswer 将关卡答题结果计入相关词汇的复习记录，答对记为“正常”，答错记为“忘记”
+func (s *ReviewService) RecordAnswer(userID string, vocabularyIDs []int64, correct bool, now time.Time) error {
+	grade := GradeAgain
+	if correct {
+		grade = GradeGood
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, vocabularyID := range vocabularyIDs {
+		if _, err := s.grade(userID, vocabularyID, grade, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Forecast 统计从今天开始每天到期的复习数量，已过期的计入今天
+func (s *ReviewService) Forecast(userID string, now time.Time, days int) ([]ReviewForecast, error) {
+	cards, err := s.store.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	result := make([]ReviewForecast, days)
+	for i := range result {
+		result[i].Date = today.AddDate(0, 0, i).Format(time.DateOnly)
+	}
+
+	for _, card := range cards {
+		day := 0
+		if card.Due.After(today) {
+			due := card.Due.In(now.Location())
+			dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
+			day = int(dueDay.Sub(today).Hours() / 24)
+		}
+		if day < days {
+			result[day].Count++
+		}
+	}
+
+	return result, nil
+}
+
+// grade 更新卡片并保存，调用方需持有锁
+func (s *ReviewService) grade(userID string, vocabularyID int64, grade ReviewGrade, now time.Time) (*ReviewCard, error) {
+	card, err := s.store.Get(userID, vocabularyID)
+	if errors.Is(err, ErrReviewCardNotFound) {
+		card = newReviewCard(userID, vocabularyID, now)
+	} else if err != nil {
+		return nil, err
+	}
+
+	s.scheduler.Schedule(card, grade, now)
+	card.UpdatedAt = now
+	if err := s.store.Save(card); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// newReviewCard 创建立即到期的新卡片
+func newReviewCard(userID string, vocabularyID int64, now time.Time) *ReviewCard {
+	return &ReviewCard{
+		UserID:       userID,
+		VocabularyID: vocabularyID,
+		Due:          now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
@@ -0,0 +1,115 @@
+package hanbao
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReviewCard 学习者对一个词汇的记忆状态
+type ReviewCard struct {
+	UserID       string    `json:"user_id"`
+	VocabularyID int64     `json:"vocabulary_id"`
+	Stability    float64   `json:"stability"`   // 记忆稳定性（天），间隔等于稳定性时回忆概率为90%
+	Difficulty   float64   `json:"difficulty"`  // 记忆难度 1-10
+	Reps         int       `json:"reps"`        // 已复习次数，0表示新卡片
+	Lapses       int       `json:"lapses"`      // 遗忘次数
+	Due          time.Time `json:"due"`         // 下次复习时间
+	LastReview   time.Time `json:"last_review"` // 上次复习时间，新卡片为零值
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ReviewStore 复习卡片存储
+type ReviewStore interface {
+	// Get 获取卡片，不存在时返回 ErrReviewCardNotFound
+	Get(userID string, vocabularyID int64) (*ReviewCard, error)
+	// Save 保存卡片，不存在时创建
+	Save(card *ReviewCard) error
+	// List 获取学习者的所有卡片
+	List(userID string) ([]ReviewCard, error)
+	// ListDue 获取在 before 之前到期的卡片，按到期时间排序，limit 小于等于0时不限制数量
+	ListDue(userID string, before time.Time, limit int) ([]ReviewCard, error)
+}
+
+// MemoryReviewStore 基于内存的复习卡片存储
+type MemoryReviewStore struct {
+	mu    sync.RWMutex
+	cards map[string]map[int64]ReviewCard
+}
+
+// NewMemoryReviewStore 创建内存复习卡片存储
+func NewMemoryReviewStore() *MemoryReviewStore {
+	return &MemoryReviewStore{
+		cards: make(map[string]map[int64]ReviewCard),
+	}
+}
+
+// Get 获取卡片
+func (s *MemoryReviewStore) Get(userID string, vocabularyID int64) (*ReviewCard, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	card, ok := s.cards[userID][vocabularyID]
+	if !ok {
+		return nil, ErrReviewCardNotFound
+	}
+	return &card, nil
+}
+
+// Save 保存卡片
+func (s *MemoryReviewStore) Save(card *ReviewCard) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cards, ok := s.cards[card.UserID]
+	if !ok {
+		cards = make(map[int64]ReviewCard)
+		s.cards[card.UserID] = cards
+	}
+	cards[card.VocabularyID] = *card
+	return nil
+}
+
+// List 获取学习者的所有卡片
+func (s *MemoryReviewStore) List(userID string) ([]ReviewCard, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]ReviewCard, 0, len(s.cards[userID]))
+	for _, card := range s.cards[userID] {
+		result = append(result, card)
+	}
+	sortCardsByDue(result)
+	return result, nil
+}
+
+// ListDue 获取到期的卡片
+func (s *MemoryReviewStore) ListDue(userID string, before time.Time, limit int) ([]ReviewCard, error) {
+	cards, err := s.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ReviewCard, 0)
+	for _, card := range cards {
+		if card.Due.After(before) {
+			break
+		}
+		result = append(result, card)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// sortCardsByDue 按到期时间排序，时间相同时按词汇ID排序
+func sortCardsByDue(cards []ReviewCard) {
+	sort.Slice(cards, func(i, j int) bool {
+		if !cards[i].Due.Equal(cards[j].Due) {
+			return cards[i].Due.Before(cards[j].Due)
+		}
+		return cards[i].VocabularyID < cards[j].VocabularyID
+	})
+}
@@ -0,0 +1,468 @@
+package hanbao
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 罗马字转写方案
+const (
+	RomajiHepburn = "hepburn" // 日语黑本式，如 し shi、ち chi
+	RomajiKunrei  = "kunrei"  // 日语训令式，如 し si、ち ti
+	RomajaRevised = "revised" // 韩语文化观光部2000年式（Revised Romanization），按实际发音转写
+)
+
+// RomanizeOptions 罗马字转写选项
+type RomanizeOptions struct {
+	Scheme  string // 转写方案，日语默认黑本式，韩语只支持 revised
+	Macrons bool   // 日语长音加长音符号：黑本式写成 ō、ū，训令式写成 ô、û；默认按假名拼写，如 gakkou
+}
+
+// Romanization 罗马字转写结果
+type Romanization struct {
+	Language  string   `json:"language"`
+	Scheme    string   `json:"scheme"`
+	Text      string   `json:"text"`                // 原文
+	Romanized string   `json:"romanized"`           // 转写结果，韩语已应用连音、鼻音化等音变
+	Syllables []string `json:"syllables,omitempty"` // 韩语逐音节转写（不做音变），即词汇 Syllables 字段的写法
+	Unknown   []string `json:"unknown,omitempty"`   // 无法转写、原样保留的字符
+}
+
+// Romanize 把假名或谚文转写成罗马字，language 为空时按文字自动判断
+func Romanize(text, language string, opts RomanizeOptions) (*Romanization, error) {
+	if language == "" {
+		language = detectRomanizeLanguage(text)
+	}
+
+	result := &Romanization{Language: language, Scheme: opts.Scheme, Text: text}
+	switch language {
+	case "ja":
+		if result.Scheme == "" {
+			result.Scheme = RomajiHepburn
+		}
+		if result.Scheme != RomajiHepburn && result.Scheme != RomajiKunrei {
+			break
+		}
+		result.Romanized, result.Unknown = kanaToRomaji(text, result.Scheme, opts.Macrons)
+		return result, nil
+	case "ko":
+		if result.Scheme == "" {
+			result.Scheme = RomajaRevised
+		}
+		if result.Scheme != RomajaRevised {
+			break
+		}
+		result.Romanized, result.Unknown = hangulToRoman(text)
+		result.Syllables = HangulSyllables(text)
+		return result, nil
+	}
+	return nil, ErrUnsupportedRomanization.WithDetails(map[string]any{"language": language, "scheme": opts.Scheme})
+}
+
+// detectRomanizeLanguage 按第一个假名或谚文字符判断语言，都没有时返回空字符串
+func detectRomanizeLanguage(text string) string {
+	for _, r := range text {
+		switch {
+		case isKana(r):
+			return "ja"
+		case isHangulSyllable(r):
+			return "ko"
+		}
+	}
+	return ""
+}
+
+// KanaToRomaji 把假名转写成罗马字，片假名按平假名处理；含有假名以外的字符时 ok 为 false
+func KanaToRomaji(kana, scheme string, macrons bool) (romaji string, ok bool) {
+	romaji, unknown := kanaToRomaji(kana, scheme, macrons)
+	return romaji, len(unknown) == 0
+}
+
+// HangulToRoman 按 Revised Romanization 转写谚文，应用连音、鼻音化、流音化、送气化和腭化，如 학년 → hangnyeon、신라 → silla
+func HangulToRoman(text string) string {
+	roman, _ := hangulToRoman(text)
+	return roman
+}
+
+// RomanizedField 由词汇的书写形式推导出的罗马字字段：日语由假名读音推导 romaji，
+// 韩语由谚文按实际发音推导 pronunciation（如 학교 → hakgyo、학년 → hangnyeon）；无法推导时 ok 为 false
+func (v Vocabulary) RomanizedField() (field, value string, ok bool) {
+	switch v.Language {
+	case "ja":
+		if v.Pronunciation == "" {
+			return "", "", false
+		}
+		romaji, ok := KanaToRomaji(v.Pronunciation, RomajiHepburn, false)
+		return "romaji", romaji, ok
+	case "ko":
+		if v.hangulSyllables() == nil {
+			return "", "", false
+		}
+		return "pronunciation", HangulToRoman(v.Word), true
+	}
+	return "", "", false
+}
+
+// hangulSyllables 韩语词汇的逐音节转写，词中含有谚文以外的字符时返回 nil
+func (v Vocabulary) hangulSyllables() []string {
+	syllables := HangulSyllables(v.Word)
+	if len(syllables) == 0 || len(syllables) != len([]rune(v.Word)) {
+		return nil
+	}
+	return syllables
+}
+
+// fillRomanization 为未填写的日语罗马字和韩语发音补充自动转写，并由谚文生成韩语逐音节读音；
+// 已填写的罗马字由内容包的 Validate 交叉校验
+func (v *Vocabulary) fillRomanization() {
+	if field, value, ok := v.RomanizedField(); ok {
+		switch {
+		case field == "romaji" && v.Romaji == "":
+			v.Romaji = value
+		case field == "pronunciation" && v.Pronunciation == "":
+			v.Pronunciation = value
+		}
+	}
+	if v.Language == "ko" {
+		v.Syllables = v.hangulSyllables()
+	}
+}
+
+// fillVocabularyRomanization 为一组词汇补充自动转写，返回同一个切片
+func fillVocabularyRomanization(vocabs []Vocabulary) []Vocabulary {
+	for i := range vocabs {
+		vocabs[i].fillRomanization()
+	}
+	return vocabs
+}
+
+// --- 假名 ---
+
+// kanaMonographs 单个假名的转写，依次为黑本式和训令式
+var kanaMonographs = map[rune][2]string{
+	'あ': {"a", "a"}, 'い': {"i", "i"}, 'う': {"u", "u"}, 'え': {"e", "e"}, 'お': {"o", "o"},
+	'か': {"ka", "ka"}, 'き': {"ki", "ki"}, 'く': {"ku", "ku"}, 'け': {"ke", "ke"}, 'こ': {"ko", "ko"},
+	'が': {"ga", "ga"}, 'ぎ': {"gi", "gi"}, 'ぐ': {"gu", "gu"}, 'げ': {"ge", "ge"}, 'ご': {"go", "go"},
+	'さ': {"sa", "sa"}, 'し': {"shi", "si"}, 'す': {"su", "su"}, 'せ': {"se", "se"}, 'そ': {"so", "so"},
+	'ざ': {"za", "za"}, 'じ': {"ji", "zi"}, 'ず': {"zu", "zu"}, 'ぜ': {"ze", "ze"}, 'ぞ': {"zo", "zo"},
+	'た': {"ta", "ta"}, 'ち': {"chi", "ti"}, 'つ': {"tsu", "tu"}, 'て': {"te", "te"}, 'と': {"to", "to"},
+	'だ': {"da", "da"}, 'ぢ': {"ji", "zi"}, 'づ': {"zu", "zu"}, 'で': {"de", "de"}, 'ど': {"do", "do"},
+	'な': {"na", "na"}, 'に': {"ni", "ni"}, 'ぬ': {"nu", "nu"}, 'ね': {"ne", "ne"}, 'の': {"no", "no"},
+	'は': {"ha", "ha"}, 'ひ': {"hi", "hi"}, 'ふ': {"fu", "hu"}, 'へ': {"he", "he"}, 'ほ': {"ho", "ho"},
+	'ば': {"ba", "ba"}, 'び': {"bi", "bi"}, 'ぶ': {"bu", "bu"}, 'べ': {"be", "be"}, 'ぼ': {"bo", "bo"},
+	'ぱ': {"pa", "pa"}, 'ぴ': {"pi", "pi"}, 'ぷ': {"pu", "pu"}, 'ぺ': {"pe", "pe"}, 'ぽ': {"po", "po"},
+	'ま': {"ma", "ma"}, 'み': {"mi", "mi"}, 'む': {"mu", "mu"}, 'め': {"me", "me"}, 'も': {"mo", "mo"},
+	'や': {"ya", "ya"}, 'ゆ': {"yu", "yu"}, 'よ': {"yo", "yo"},
+	'ら': {"ra", "ra"}, 'り': {"ri", "ri"}, 'る': {"ru", "ru"}, 'れ': {"re", "re"}, 'ろ': {"ro", "ro"},
+	'わ': {"wa", "wa"}, 'ゐ': {"i", "i"}, 'ゑ': {"e", "e"}, 'を': {"o", "o"}, 'ん': {"n", "n"},
+	'ゔ': {"vu", "vu"},
+	'ぁ': {"a", "a"}, 'ぃ': {"i", "i"}, 'ぅ': {"u", "u"}, 'ぇ': {"e", "e"}, 'ぉ': {"o", "o"},
+	'ゃ': {"ya", "ya"}, 'ゅ': {"yu", "yu"}, 'ょ': {"yo", "yo"}, 'ゎ': {"wa", "wa"},
+}
+
+// kanaYoonStems 拗音的声母部分，与小写的 ゃ ゅ ょ 组合，如 しゃ → sha / sya
+var kanaYoonStems = map[rune][2]string{
+	'き': {"ky", "ky"}, 'ぎ': {"gy", "gy"}, 'し': {"sh", "sy"}, 'じ': {"j", "zy"},
+	'ち': {"ch", "ty"}, 'ぢ': {"j", "zy"}, 'に': {"ny", "ny"}, 'ひ': {"hy", "hy"},
+	'び': {"by", "by"}, 'ぴ': {"py", "py"}, 'み': {"my", "my"}, 'り': {"ry", "ry"},
+}
+
+// kanaExtendedDigraphs 外来语中用小写元音组成的音节，多见于片假名，如 ファ fa、ティ ti
+var kanaExtendedDigraphs = map[string][2]string{
+	"ふぁ": {"fa", "fa"}, "ふぃ": {"fi", "fi"}, "ふぇ": {"fe", "fe"}, "ふぉ": {"fo", "fo"},
+	"てぃ": {"ti", "ti"}, "でぃ": {"di", "di"}, "とぅ": {"tu", "tu"}, "どぅ": {"du", "du"},
+	"うぃ": {"wi", "wi"}, "うぇ": {"we", "we"}, "うぉ": {"wo", "wo"}, "いぇ": {"ye", "ye"},
+	"しぇ": {"she", "sye"}, "じぇ": {"je", "zye"}, "ちぇ": {"che", "tye"},
+	"ゔぁ": {"va", "va"}, "ゔぃ": {"vi", "vi"}, "ゔぇ": {"ve", "ve"}, "ゔぉ": {"vo", "vo"},
+}
+
+// 长音符号，依次为黑本式和训令式
+var (
+	hepburnMacrons = map[rune]rune{'a': 'ā', 'i': 'ī', 'u': 'ū', 'e': 'ē', 'o': 'ō'}
+	kunreiMacrons  = map[rune]rune{'a': 'â', 'i': 'î', 'u': 'û', 'e': 'ê', 'o': 'ô'}
+)
+
+// kanaToRomaji 逐个音节转写假名，处理促音 っ、拨音 ん 和长音，返回转写结果和无法转写的字符
+func kanaToRomaji(kana, scheme string, macrons bool) (string, []string) {
+	column := 0
+	if scheme == RomajiKunrei {
+		column = 1
+	}
+
+	runes := []rune(toHiragana(kana))
+	var out []rune
+	var unknown []string
+	geminate, afterN := false, false
+	var lastVowel rune
+	for i := 0; i < len(runes); {
+		syllable, n := kanaSyllable(runes[i:], column)
+		if n == 0 {
+			switch r := runes[i]; {
+			case r == 'っ':
+				geminate = true
+			case r == 'ー' && lastVowel != 0:
+				out = lengthenVowel(out, lastVowel, scheme, macrons)
+				lastVowel = 0
+			default:
+				if !unicode.IsSpace(r) && !unicode.IsPunct(r) {
+					unknown = append(unknown, string(r))
+				}
+				out = append(out, r)
+				lastVowel, afterN = 0, false
+			}
+			i++
+			continue
+		}
+		i += n
+
+		first := rune(syllable[0])
+		switch {
+		case afterN && strings.ContainsRune("aiueoy", first):
+			out = append(out, '\'')
+		case geminate && !strings.ContainsRune("aiueon", first):
+			if column == 0 && strings.HasPrefix(syllable, "ch") {
+				out = append(out, 't')
+			} else {
+				out = append(out, first)
+			}
+		}
+		geminate = false
+
+		if macrons && len(syllable) == 1 && isLongVowel(lastVowel, first, scheme) {
+			out = lengthenVowel(out, lastVowel, scheme, true)
+			lastVowel, afterN = 0, false
+			continue
+		}
+
+		out = append(out, []rune(syllable)...)
+		afterN = syllable == "n"
+		lastVowel = 0
+		if last := rune(syllable[len(syllable)-1]); strings.ContainsRune("aiueo", last) {
+			lastVowel = last
+		}
+	}
+	return string(out), unknown
+}
+
+// kanaSyllable 从开头取一个音节，返回转写和占用的假名数，不是可转写的假名时返回 0
+func kanaSyllable(runes []rune, column int) (string, int) {
+	if len(runes) >= 2 {
+		if v, ok := kanaExtendedDigraphs[string(runes[:2])]; ok {
+			return v[column], 2
+		}
+		if stem, ok := kanaYoonStems[runes[0]]; ok {
+			if small, ok := map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}[runes[1]]; ok {
+				return stem[column] + small, 2
+			}
+		}
+	}
+	if v, ok := kanaMonographs[runes[0]]; ok {
+		return v[column], 1
+	}
+	return "", 0
+}
+
+// isLongVowel 前一个元音与当前元音是否构成长音：aa、uu、ee、oo、ou；黑本式的 ii 不标长音
+func isLongVowel(prev, cur rune, scheme string) bool {
+	if prev == 0 {
+		return false
+	}
+	if prev == 'o' && cur == 'u' {
+		return true
+	}
+	return prev == cur && (cur != 'i' || scheme == RomajiKunrei)
+}
+
+// lengthenVowel 把最后一个元音变成长音：加长音符号，或不加符号时重复元音（用于 ー）
+func lengthenVowel(out []rune, vowel rune, scheme string, macrons bool) []rune {
+	if !macrons {
+		return append(out, vowel)
+	}
+	marks := hepburnMacrons
+	if scheme == RomajiKunrei {
+		marks = kunreiMacrons
+	}
+	out[len(out)-1] = marks[vowel]
+	return out
+}
+
+// toHiragana 片假名转换为平假名，其他字符不变
+func toHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+// isKana 是否为平假名或片假名（含长音符号）
+func isKana(r rune) bool {
+	return unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+// --- 谚文 ---
+
+// 谚文音节的组成，音节 = 0xAC00 + (初声×21 + 中声)×28 + 终声
+const (
+	hangulBase      = 0xAC00
+	hangulLast      = 0xD7A3
+	hangulVowels    = 21
+	hangulFinals    = 28
+	hangulSilent    = 11 // 初声 ㅇ
+	hangulInitialL  = 5  // 初声 ㄹ
+	hangulInitialN  = 2  // 初声 ㄴ
+	hangulInitialM  = 6  // 初声 ㅁ
+	hangulVowelI    = 20 // 中声 ㅣ
+	hangulFinalNone = 0
+)
+
+var (
+	// hangulInitialRoman 初声，按 ㄱ ㄲ ㄴ ㄷ ㄸ ㄹ ㅁ ㅂ ㅃ ㅅ ㅆ ㅇ ㅈ ㅉ ㅊ ㅋ ㅌ ㅍ ㅎ 排列
+	hangulInitialRoman = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	// hangulVowelRoman 中声，按 ㅏ ㅐ ㅑ ㅒ ㅓ ㅔ ㅕ ㅖ ㅗ ㅘ ㅙ ㅚ ㅛ ㅜ ㅝ ㅞ ㅟ ㅠ ㅡ ㅢ ㅣ 排列
+	hangulVowelRoman = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	// hangulFinalRoman 终声的代表音，按 (无) ㄱ ㄲ ㄳ ㄴ ㄵ ㄶ ㄷ ㄹ ㄺ ㄻ ㄼ ㄽ ㄾ ㄿ ㅀ ㅁ ㅂ ㅄ ㅅ ㅆ ㅇ ㅈ ㅊ ㅋ ㅌ ㅍ ㅎ 排列
+	hangulFinalRoman = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+	// hangulLiaison 后一音节以 ㅇ 开头时终声的连音：留下的终声和移到后一音节的初声，双终声只移后一个
+	hangulLiaison = [][2]string{
+		{"", ""}, {"", "g"}, {"", "kk"}, {"k", "s"}, {"", "n"}, {"n", "j"}, {"", "n"}, {"", "d"}, {"", "r"}, {"l", "g"},
+		{"l", "m"}, {"l", "b"}, {"l", "s"}, {"l", "t"}, {"l", "p"}, {"", "r"}, {"", "m"}, {"", "b"}, {"p", "s"}, {"", "s"},
+		{"", "ss"}, {"ng", ""}, {"", "j"}, {"", "ch"}, {"", "k"}, {"", "t"}, {"", "p"}, {"", ""},
+	}
+	// hangulNasals 塞音终声在鼻音前的鼻音化，如 학년 hangnyeon、입문 immun
+	hangulNasals = map[string]string{"k": "ng", "t": "n", "p": "m"}
+	// hangulAspirated ㅎ 终声与后一音节初声 ㄱ ㄷ ㅈ ㅅ 的送气化（ㅅ 为紧音化），如 좋고 joko
+	hangulAspirated = map[int]string{0: "k", 3: "t", 12: "ch", 9: "ss"}
+)
+
+// hangulSyllable 谚文音节的初声、中声、终声
+type hangulSyllable struct {
+	initial, vowel, final int
+}
+
+// isHangulSyllable 是否为完整的谚文音节
+func isHangulSyllable(r rune) bool {
+	return r >= hangulBase && r <= hangulLast
+}
+
+// decomposeHangul 分解谚文音节
+func decomposeHangul(r rune) hangulSyllable {
+	n := int(r - hangulBase)
+	return hangulSyllable{
+		initial: n / (hangulVowels * hangulFinals),
+		vowel:   n % (hangulVowels * hangulFinals) / hangulFinals,
+		final:   n % hangulFinals,
+	}
+}
+
+// HangulSyllables 逐音节转写谚文，不做音变，如 학교 → [hak gyo]；非谚文字符跳过
+func HangulSyllables(text string) []string {
+	var result []string
+	for _, r := range text {
+		if !isHangulSyllable(r) {
+			continue
+		}
+		s := decomposeHangul(r)
+		result = append(result, hangulInitialRoman[s.initial]+hangulVowelRoman[s.vowel]+hangulFinalRoman[s.final])
+	}
+	return result
+}
+
+// hangulToRoman 按实际发音转写谚文，连续的谚文音节视为一个词，其他字符原样保留
+func hangulToRoman(text string) (string, []string) {
+	var b strings.Builder
+	var unknown []string
+	var word []hangulSyllable
+	flush := func() {
+		b.WriteString(romanizeHangulWord(word))
+		word = word[:0]
+	}
+	for _, r := range text {
+		if isHangulSyllable(r) {
+			word = append(word, decomposeHangul(r))
+			continue
+		}
+		flush()
+		if !unicode.IsSpace(r) && !unicode.IsPunct(r) && !unicode.IsDigit(r) && r > unicode.MaxASCII {
+			unknown = append(unknown, string(r))
+		}
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String(), unknown
+}
+
+// romanizeHangulWord 转写一个词，逐个处理音节之间的音变
+func romanizeHangulWord(word []hangulSyllable) string {
+	var b strings.Builder
+	initial := ""
+	for i, s := range word {
+		if i == 0 {
+			initial = hangulInitialRoman[s.initial]
+		}
+		final := hangulFinalRoman[s.final]
+		next := ""
+		if i+1 < len(word) {
+			final, next = hangulJunction(s, word[i+1])
+		}
+		b.WriteString(initial + hangulVowelRoman[s.vowel] + final)
+		initial = next
+	}
+	return b.String()
+}
+
+// hangulJunction 相邻两个音节之间的音变，返回前一音节的终声和后一音节的初声；
+// 按 Revised Romanization 的规定，名词中 ㄱ ㄷ ㅂ 后的 ㅎ 不标送气（축하 chukha），紧音化也不标出
+func hangulJunction(cur, next hangulSyllable) (final, initial string) {
+	final, initial = hangulFinalRoman[cur.final], hangulInitialRoman[next.initial]
+	if cur.final == hangulFinalNone {
+		return final, initial
+	}
+
+	switch {
+	case next.initial == hangulSilent:
+		// 连音，ㄷ ㅌ ㄾ 在 이 前腭化，如 같이 gachi
+		liaison := hangulLiaison[cur.final]
+		if next.vowel == hangulVowelI {
+			switch liaison[1] {
+			case "d":
+				liaison[1] = "j"
+			case "t":
+				liaison[1] = "ch"
+			}
+		}
+		return liaison[0], liaison[1]
+	case cur.final == 27 || cur.final == 6 || cur.final == 15:
+		// ㅎ ㄶ ㅀ 终声：ㅎ 与后面的辅音合成送气音，在 ㄴ 前同化
+		rest := map[int]string{27: "", 6: "n", 15: "l"}[cur.final]
+		if aspirated, ok := hangulAspirated[next.initial]; ok {
+			return rest, aspirated
+		}
+		if next.initial == hangulInitialN {
+			if rest == "l" {
+				return "l", "l"
+			}
+			return "n", "n"
+		}
+		return rest, initial
+	case next.initial == hangulInitialL:
+		// ㄴ/ㄹ + ㄹ 流音化，其他终声后 ㄹ 读作 ㄴ，塞音终声随之鼻音化，如 신라 silla、종로 jongno、국립 gungnip
+		if final == "n" || final == "l" {
+			return "l", "l"
+		}
+		if nasal, ok := hangulNasals[final]; ok {
+			return nasal, "n"
+		}
+		return final, "n"
+	case next.initial == hangulInitialN || next.initial == hangulInitialM:
+		// ㄹ + ㄴ 流音化，塞音终声在鼻音前鼻音化，如 설날 seollal、학년 hangnyeon
+		if final == "l" && next.initial == hangulInitialN {
+			return "l", "l"
+		}
+		if nasal, ok := hangulNasals[final]; ok {
+			return nasal, initial
+		}
+	}
+	return final, initial
+}
@@ -0,0 +1,25 @@
+package hanbao
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFillKoreanRomanization(t *testing.T) {
+	vocabs := fillVocabularyRomanization([]Vocabulary{
+		{Language: "ko", Word: "학년", Hanja: "學年"},
+		{Language: "ko", Word: "전화", Hanja: "電話", Pronunciation: "jeonhwa"},
+	})
+
+	if got := vocabs[0].Pronunciation; got != "hangnyeon" {
+		t.Errorf("Pronunciation = %q, want %q", got, "hangnyeon")
+	}
+	if got, want := vocabs[0].Syllables, []string{"hak", "nyeon"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Syllables = %v, want %v", got, want)
+	}
+	for i, want := range []string{"jeon", "hwa"} {
+		if got := vocabs[1].HanjaSyllables()[i].Reading; got != want {
+			t.Errorf("HanjaSyllables()[%d].Reading = %q, want %q", i, got, want)
+		}
+	}
+}
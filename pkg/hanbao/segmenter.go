@@ -0,0 +1,180 @@
+package hanbao
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// IsHanIdeograph 是否为汉字（Unicode Han 文字中的表意字符，包括扩展A-H区和兼容汉字），
+// 不包括部首、々 等符号以及假名、谚文、全角标点和 emoji
+func IsHanIdeograph(r rune) bool {
+	return unicode.Is(unicode.Han, r) && unicode.Is(unicode.Ideographic, r)
+}
+
+// Segment 分词结果中的一个词
+type Segment struct {
+	Text    string  `json:"text"`
+	Known   bool    `json:"known"`              // 是否为词典中的词（各语言词汇、韩语汉字写法、中文词）
+	RootIDs []int64 `json:"root_ids,omitempty"` // 词中包含的字根，按出现顺序排列
+}
+
+// Segmenter 基于词典的分词器，用正向最大匹配把自由输入的句子切分成词，
+// 词典由所有词汇的词形、韩语汉字写法和中文词组成，不区分大小写
+type Segmenter struct {
+	dictionary  map[string][]int64 // 词 → 组成字根
+	rootsByChar map[string]int64
+	maxLen      int // 词典中最长的词（按字符数）
+}
+
+// NewSegmenter 根据字根和词汇创建分词器
+func NewSegmenter(roots []CharacterRoot, vocabularies []Vocabulary) *Segmenter {
+	s := &Segmenter{
+		dictionary:  make(map[string][]int64),
+		rootsByChar: make(map[string]int64, len(roots)),
+	}
+	for _, root := range roots {
+		s.rootsByChar[root.Root] = root.ID
+	}
+	// 繁体字、日本新字体和韩国汉字也能匹配字根，与其他字根的简体字相同时以简体字为准
+	for _, root := range roots {
+		for _, form := range root.Forms() {
+			if _, exists := s.rootsByChar[form]; !exists {
+				s.rootsByChar[form] = root.ID
+			}
+		}
+	}
+	for _, vocab := range vocabularies {
+		for _, form := range []string{vocab.Word, vocab.Hanja, vocab.Chinese} {
+			if form != "" {
+				s.addWord(form, vocab.RootIDs())
+			}
+		}
+	}
+	return s
+}
+
+// addWord 把词加入词典，同一个词形的字根合并
+func (s *Segmenter) addWord(word string, rootIDs []int64) {
+	key := segmentKey(word)
+	ids := s.dictionary[key]
+	for _, id := range rootIDs {
+		ids = appendUniqueID(ids, id)
+	}
+	s.dictionary[key] = ids
+	s.maxLen = max(s.maxLen, len([]rune(key)))
+}
+
+// Segment 把文本切分成词：词典中的词整体切出；没有收录的汉字逐字切分；
+// 没有收录的假名、谚文和拼音文字按连续的同类字符切分；空白、标点和符号不计入结果
+func (s *Segmenter) Segment(text string) []Segment {
+	runes := []rune(norm.NFC.String(text))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var segments []Segment
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if !isWordRune(r) {
+			i++
+			continue
+		}
+
+		if n, ids := s.match(runes, lower, i); n > 0 {
+			segments = append(segments, s.segment(string(runes[i:i+n]), true, ids))
+			i += n
+			continue
+		}
+
+		j := i + 1
+		if !IsHanIdeograph(r) {
+			for j < len(runes) && isWordRune(runes[j]) && scriptClass(runes[j]) == scriptClass(r) {
+				if n, _ := s.match(runes, lower, j); n > 0 {
+					break
+				}
+				j++
+			}
+		}
+		segments = append(segments, s.segment(string(runes[i:j]), false, nil))
+		i = j
+	}
+	return segments
+}
+
+// match 从 start 开始匹配词典中最长的词，返回匹配的字符数和字根；拼音文字的词必须在词边界上
+func (s *Segmenter) match(runes, lower []rune, start int) (int, []int64) {
+	for n := min(s.maxLen, len(runes)-start); n > 0; n-- {
+		ids, ok := s.dictionary[string(lower[start:start+n])]
+		if !ok {
+			continue
+		}
+		end := start + n
+		if scriptClass(runes[start]) == scriptAlphabetic && start > 0 && isWordRune(runes[start-1]) {
+			continue
+		}
+		if scriptClass(runes[end-1]) == scriptAlphabetic && end < len(runes) && isWordRune(runes[end]) {
+			continue
+		}
+		return n, ids
+	}
+	return 0, nil
+}
+
+// segment 生成分词结果，词中收录为字根的汉字也计入字根
+func (s *Segmenter) segment(text string, known bool, rootIDs []int64) Segment {
+	ids := append([]int64(nil), rootIDs...)
+	for _, r := range text {
+		if !IsHanIdeograph(r) {
+			continue
+		}
+		if id, ok := s.rootsByChar[string(r)]; ok {
+			ids = appendUniqueID(ids, id)
+		}
+	}
+	return Segment{Text: text, Known: known, RootIDs: ids}
+}
+
+// 文字类别，连续的同类字符视为一个未收录的词
+const (
+	scriptHan = iota
+	scriptKana
+	scriptHangul
+	scriptAlphabetic // 拉丁字母等以空格分词的文字，以及数字
+)
+
+// scriptClass 字符的文字类别
+func scriptClass(r rune) int {
+	switch {
+	case IsHanIdeograph(r):
+		return scriptHan
+	case isKana(r):
+		return scriptKana
+	case unicode.Is(unicode.Hangul, r):
+		return scriptHangul
+	default:
+		return scriptAlphabetic
+	}
+}
+
+// isWordRune 是否为组成词的字符（文字、数字、组合符号和长音符号）
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == 'ー'
+}
+
+// segmentKey 词典的键：NFC 规范化并转为小写
+func segmentKey(word string) string {
+	return strings.ToLower(norm.NFC.String(strings.TrimSpace(word)))
+}
+
+// appendUniqueID 追加不重复的ID
+func appendUniqueID(ids []int64, id int64) []int64 {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
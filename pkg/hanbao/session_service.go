@@ -0,0 +1,147 @@
+package hanbao
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 会话状态
+const (
+	SessionStatusActive    = "active"
+	SessionStatusCompleted = "completed"
+)
+
+// SessionService 会话服务，记录用户解锁的字根和答题进度
+type SessionService struct {
+	store SessionStore
+	mu    sync.Mutex // 保护同一进程内对会话的读-改-写
+}
+
+// NewSessionService 创建会话服务
+func NewSessionService(store SessionStore) *SessionService {
+	return &SessionService{
+		store: store,
+	}
+}
+
+// StartSession 开始新会话
+func (s *SessionService) StartSession(userID string) (*UserSession, error) {
+	now := time.Now()
+	session := &UserSession{
+		ID:                uuid.New().String(),
+		UserID:            userID,
+		UnlockedRoots:     []int64{},
+		CompletedLevels:   []string{},
+		AnsweredQuestions: []string{},
+		StartTime:         now,
+		LastActive:        now,
+		Status:            SessionStatusActive,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.store.Save(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSession 获取会话
+func (s *SessionService) GetSession(sessionID string) (*UserSession, error) {
+	return s.store.Get(sessionID)
+}
+
+// RecordUnlockedRoots 记录解锁仪式中发现的字根
+func (s *SessionService) RecordUnlockedRoots(sessionID string, roots []CharacterRoot) (*UserSession, error) {
+	return s.update(sessionID, func(session *UserSession) error {
+		unlocked := make(map[int64]bool, len(session.UnlockedRoots))
+		for _, rootID := range session.UnlockedRoots {
+			unlocked[rootID] = true
+		}
+		for _, root := range roots {
+			if !unlocked[root.ID] {
+				unlocked[root.ID] = true
+				session.UnlockedRoots = append(session.UnlockedRoots, root.ID)
+			}
+		}
+		return nil
+	})
+}
+
+// RecordAnswer 记录一次答题结果；同一会话中每个问题只记录一次，已作答时返回 ErrAnswerAlreadySubmitted，
+// 因此关卡答题记录过期或使用答案令牌答题时也不能重复得分。
+// 结果带有关卡的所有问题ID时（答案令牌模式），根据会话中已作答的问题判断关卡是否完成，并写回 result.LevelCompleted
+func (s *SessionService) RecordAnswer(sessionID, questionID string, result *AnswerResult) (*UserSession, error) {
+	return s.update(sessionID, func(session *UserSession) error {
+		answered := answeredQuestionKey(result.LevelID, questionID)
+		if containsString(session.AnsweredQuestions, answered) {
+			return ErrAnswerAlreadySubmitted
+		}
+		session.AnsweredQuestions = append(session.AnsweredQuestions, answered)
+		if len(result.QuestionIDs) > 0 {
+			result.LevelCompleted = session.answeredAll(result.LevelID, result.QuestionIDs)
+		}
+
+		session.TotalAnswers++
+		if result.Correct {
+			session.CorrectAnswers++
+		}
+		session.Score += result.Score
+		session.Accuracy = float64(session.CorrectAnswers) / float64(session.TotalAnswers) * 100
+
+		if result.LevelCompleted && !containsString(session.CompletedLevels, result.LevelID) {
+			session.CompletedLevels = append(session.CompletedLevels, result.LevelID)
+		}
+		return nil
+	})
+}
+
+// answeredAll 会话是否已回答关卡的所有问题
+func (s *UserSession) answeredAll(levelID string, questionIDs []string) bool {
+	for _, questionID := range questionIDs {
+		if !containsString(s.AnsweredQuestions, answeredQuestionKey(levelID, questionID)) {
+			return false
+		}
+	}
+	return true
+}
+
+// answeredQuestionKey 会话中已作答问题的标识
+func answeredQuestionKey(levelID, questionID string) string {
+	return levelID + "/" + questionID
+}
+
+// update 读取会话、修改并保存
+func (s *SessionService) update(sessionID string, fn func(session *UserSession) error) (*UserSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.store.Get(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(session); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session.LastActive = now
+	session.UpdatedAt = now
+
+	if err := s.store.Save(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// containsString 判断切片中是否包含指定字符串
+func containsString(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
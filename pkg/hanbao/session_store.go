@@ -0,0 +1,91 @@
+package hanbao
+
+import (
+	"time"
+
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/syncx"
+)
+
+const sessionCacheKeyPrefix = "hanbao:session:"
+
+// SessionStore 用户会话存储
+type SessionStore interface {
+	// Get 获取会话，不存在时返回 ErrSessionNotFound
+	Get(sessionID string) (*UserSession, error)
+	// Save 保存会话，不存在时创建
+	Save(session *UserSession) error
+}
+
+// MemorySessionStore 基于内存的会话存储，过期会话自动清理
+type MemorySessionStore struct {
+	sessions *collection.Cache
+}
+
+// NewMemorySessionStore 创建内存会话存储
+func NewMemorySessionStore(expire time.Duration) (*MemorySessionStore, error) {
+	sessions, err := collection.NewCache(expire, collection.WithName("hanbao-session"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemorySessionStore{
+		sessions: sessions,
+	}, nil
+}
+
+// Get 获取会话
+func (s *MemorySessionStore) Get(sessionID string) (*UserSession, error) {
+	val, ok := s.sessions.Get(sessionID)
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	session := cloneSession(val.(*UserSession))
+	return session, nil
+}
+
+// Save 保存会话
+func (s *MemorySessionStore) Save(session *UserSession) error {
+	s.sessions.Set(session.ID, cloneSession(session))
+	return nil
+}
+
+// RedisSessionStore 基于Redis缓存的会话存储
+type RedisSessionStore struct {
+	cache  cache.Cache
+	expire time.Duration
+}
+
+// NewRedisSessionStore 创建Redis会话存储，使用配置中的 Cache 集群
+func NewRedisSessionStore(c cache.CacheConf, expire time.Duration) *RedisSessionStore {
+	return &RedisSessionStore{
+		cache: cache.New(c, syncx.NewSingleFlight(), cache.NewStat("hanbao-session"),
+			ErrSessionNotFound, cache.WithExpiry(expire)),
+		expire: expire,
+	}
+}
+
+// Get 获取会话
+func (s *RedisSessionStore) Get(sessionID string) (*UserSession, error) {
+	var session UserSession
+	if err := s.cache.Get(sessionCacheKeyPrefix+sessionID, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Save 保存会话，每次保存都会刷新过期时间
+func (s *RedisSessionStore) Save(session *UserSession) error {
+	return s.cache.SetWithExpire(sessionCacheKeyPrefix+session.ID, session, s.expire)
+}
+
+// cloneSession 复制会话，避免调用方修改存储中的数据
+func cloneSession(session *UserSession) *UserSession {
+	clone := *session
+	clone.UnlockedRoots = append([]int64(nil), session.UnlockedRoots...)
+	clone.CompletedLevels = append([]string(nil), session.CompletedLevels...)
+	clone.AnsweredQuestions = append([]string(nil), session.AnsweredQuestions...)
+	return &clone
+}
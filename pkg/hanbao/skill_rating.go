@@ -0,0 +1,134 @@
+package hanbao
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLearnerRating 新学习者的初始评分
+	defaultLearnerRating = 1000.0
+	// rootDifficultyRating 字根难度每高一级，题目的初始评分增加的分数
+	rootDifficultyRating = 100.0
+)
+
+// SkillRating 学习者或题目的 Elo 评分
+type SkillRating struct {
+	Subject   string    `json:"subject" db:"subject"`   // 评分对象，学习者为 learner:类型:用户ID，题目为 item:类型:字根ID
+	Rating    float64   `json:"rating" db:"rating"`     // 评分，学习者越高能力越强，题目越高越难
+	Attempts  int       `json:"attempts" db:"attempts"` // 已计入的答题次数
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// learnerSubject 学习者在某种关卡类型上的评分对象，不同关卡考查的能力不同，分开评分
+func learnerSubject(userID, levelType string) string {
+	return fmt.Sprintf("learner:%s:%s", levelType, userID)
+}
+
+// itemSubject 某个字根的某种关卡的评分对象
+func itemSubject(levelType string, rootID int64) string {
+	return fmt.Sprintf("item:%s:%d", levelType, rootID)
+}
+
+// RatingStore 能力评分存储
+type RatingStore interface {
+	// Get 获取评分，不存在时第二个返回值为 false
+	Get(subject string) (*SkillRating, bool, error)
+	// Save 保存评分，不存在时创建
+	Save(rating *SkillRating) error
+}
+
+// MemoryRatingStore 基于内存的能力评分存储
+type MemoryRatingStore struct {
+	mu      sync.RWMutex
+	ratings map[string]SkillRating
+}
+
+// NewMemoryRatingStore 创建内存能力评分存储
+func NewMemoryRatingStore() *MemoryRatingStore {
+	return &MemoryRatingStore{
+		ratings: make(map[string]SkillRating),
+	}
+}
+
+// Get 获取评分
+func (s *MemoryRatingStore) Get(subject string) (*SkillRating, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rating, ok := s.ratings[subject]
+	if !ok {
+		return nil, false, nil
+	}
+	return &rating, true, nil
+}
+
+// Save 保存评分
+func (s *MemoryRatingStore) Save(rating *SkillRating) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ratings[rating.Subject] = *rating
+	return nil
+}
+
+// EloModel Elo 能力模型，学习者答题相当于与题目对局，答对时学习者评分上升、题目评分下降
+type EloModel struct {
+	KFactor        float64 // 评分稳定后的K值，越大评分变化越快
+	TargetSuccess  float64 // 选择关卡时期望的答对概率
+	DifficultyStep float64 // 关卡难度每提高一级，题目的有效评分增加的分数
+}
+
+// NewEloModel 创建 Elo 能力模型
+func NewEloModel(kFactor, targetSuccess, difficultyStep float64) *EloModel {
+	return &EloModel{
+		KFactor:        kFactor,
+		TargetSuccess:  targetSuccess,
+		DifficultyStep: difficultyStep,
+	}
+}
+
+// Expected 学习者答对某难度题目的概率
+func (m *EloModel) Expected(learner, item float64, difficulty int) float64 {
+	return 1 / (1 + math.Pow(10, (m.effectiveRating(item, difficulty)-learner)/400))
+}
+
+// BestDifficulty 答对概率最接近 TargetSuccess 的关卡难度
+func (m *EloModel) BestDifficulty(learner, item float64) int {
+	// 反解 Expected = TargetSuccess 得到题目的理想有效评分
+	ideal := learner - 400*math.Log10(m.TargetSuccess/(1-m.TargetSuccess))
+	steps := 0.0
+	if m.DifficultyStep > 0 {
+		steps = (ideal - item) / m.DifficultyStep
+	}
+	return normalizeDifficulty(MinLevelDifficulty + int(math.Round(steps)))
+}
+
+// Update 根据答题结果更新学习者和题目的评分
+func (m *EloModel) Update(learner, item *SkillRating, difficulty int, correct bool, now time.Time) {
+	expected := m.Expected(learner.Rating, item.Rating, difficulty)
+	actual := 0.0
+	if correct {
+		actual = 1
+	}
+
+	learner.Rating += m.kFactor(learner.Attempts) * (actual - expected)
+	item.Rating -= m.kFactor(item.Attempts) * (actual - expected)
+
+	learner.Attempts++
+	item.Attempts++
+	learner.UpdatedAt = now
+	item.UpdatedAt = now
+}
+
+// effectiveRating 题目在指定难度下的有效评分
+func (m *EloModel) effectiveRating(item float64, difficulty int) float64 {
+	return item + float64(normalizeDifficulty(difficulty)-MinLevelDifficulty)*m.DifficultyStep
+}
+
+// kFactor 答题次数少时评分还不准确，使用更大的K值让评分快速收敛
+func (m *EloModel) kFactor(attempts int) float64 {
+	return m.KFactor * (1 + 2*math.Exp(-float64(attempts)/10))
+}
@@ -0,0 +1,621 @@
+package hanbao
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 读音对应规律作用的音节部分
+const (
+	SoundPartInitial = "initial" // 声母
+	SoundPartFinal   = "final"   // 韵尾
+)
+
+// 推测读音的依据
+const (
+	PredictionFromMiddleChinese = "middle_chinese" // 根据中古汉语拟音推测
+	PredictionFromPinyin        = "pinyin"         // 没有中古汉语拟音时根据普通话拼音粗略推测
+)
+
+// SoundRule 汉字的中古汉语读音在韩语、日语音读中的对应规律；对应形式用罗马字书写，
+// 声母的空字符串表示零声母，韵尾的空字符串表示以元音结尾
+type SoundRule struct {
+	ID       string   `json:"id"`
+	Part     string   `json:"part"`     // initial 或 final
+	From     []string `json:"from"`     // 中古汉语的声母或韵尾（白一平转写）
+	Before   []string `json:"-"`        // 只在声母后紧跟这些音时适用，如腭化只发生在 e、i 之前
+	Korean   []string `json:"korean"`   // 韩语罗马字中的对应形式，第一个最常见
+	Japanese []string `json:"japanese"` // 日语音读罗马字中的对应形式，第一个最常见
+	Note     string   `json:"note"`
+	Example  string   `json:"example"`
+}
+
+// SoundRules 中古汉语到韩语汉字音、日语音读的主要对应规律，同一部分中靠前的规则优先匹配；
+// 元音的对应关系比较复杂，这里只描述声母和韵尾
+var SoundRules = []SoundRule{
+	{ID: "initial_labial", Part: SoundPartInitial, From: []string{"p", "ph", "b"},
+		Korean: []string{"b", "p"}, Japanese: []string{"h", "b", "f"},
+		Note: "双唇塞音在韩语读 b/p；日语古时读 p，后来变成 h", Example: "發 bal / hatsu"},
+	{ID: "initial_m", Part: SoundPartInitial, From: []string{"m"},
+		Korean: []string{"m"}, Japanese: []string{"b", "m"},
+		Note: "m 在韩语保留；日语汉音读 b，吴音读 m", Example: "文 mun / bun、mon"},
+	{ID: "initial_dental_palatal", Part: SoundPartInitial, From: []string{"t", "th", "d"}, Before: []string{"e", "i", "j"},
+		Korean: []string{"j", "ch"}, Japanese: []string{"d", "t", "ch", "j"},
+		Note: "舌音在 e、i 前，韩语后来腭化成 j/ch，日语仍读 t/d", Example: "電 jeon / den"},
+	{ID: "initial_dental", Part: SoundPartInitial, From: []string{"t", "th", "d"},
+		Korean: []string{"d", "t"}, Japanese: []string{"t", "d", "z"},
+		Note: "舌音在韩语读 d/t，日语读 t/d", Example: "圖 do / to、zu"},
+	{ID: "initial_retroflex", Part: SoundPartInitial, From: []string{"tr", "trh", "dr"},
+		Korean: []string{"j", "ch"}, Japanese: []string{"ch", "j", "t"},
+		Note: "卷舌的舌上音在韩语读 j/ch，日语读 ch/j", Example: "中 jung / chuu"},
+	{ID: "initial_nasal_dental", Part: SoundPartInitial, From: []string{"n", "nr"},
+		Korean: []string{"n", "y", ""}, Japanese: []string{"n", "d", "j"},
+		Note: "n 在韩语保留，在 i、y 前常脱落；日语吴音读 n，汉音读 d", Example: "男 nam / dan、nan"},
+	{ID: "initial_l", Part: SoundPartInitial, From: []string{"l"},
+		Korean: []string{"n", "r", "y", ""}, Japanese: []string{"r"},
+		Note: "l 在韩语词首读 n 或脱落，词中读 r；日语读 r", Example: "老 no / rou"},
+	{ID: "initial_affricate", Part: SoundPartInitial,
+		From:   []string{"ts", "tsh", "dz", "tsr", "tsrh", "dzr", "tsy", "tshy", "dzy"},
+		Korean: []string{"j", "ch", "s"}, Japanese: []string{"s", "sh", "z", "j"},
+		Note: "塞擦音在韩语读 j/ch，日语读 s/sh/z", Example: "子 ja / shi"},
+	{ID: "initial_sibilant", Part: SoundPartInitial, From: []string{"s", "z", "sr", "zr", "sy", "zy"},
+		Korean: []string{"s"}, Japanese: []string{"s", "sh", "z", "j"},
+		Note: "擦音在韩语读 s，日语读 s/sh", Example: "生 saeng / sei、書 seo / sho"},
+	{ID: "initial_ny", Part: SoundPartInitial, From: []string{"ny"},
+		Korean: []string{"", "y"}, Japanese: []string{"n", "j"},
+		Note: "日母在韩语脱落，日语吴音读 n，汉音读 j", Example: "日 il / nichi、jitsu"},
+	{ID: "initial_velar", Part: SoundPartInitial, From: []string{"k", "kh", "g"},
+		Korean: []string{"g", "k"}, Japanese: []string{"k", "g"},
+		Note: "舌根音在三种语言中都比较稳定，普通话在 i、ü 前腭化成 j/q", Example: "國 guk / koku、家 ga / ka"},
+	{ID: "initial_ng", Part: SoundPartInitial, From: []string{"ng"},
+		Korean: []string{""}, Japanese: []string{"g"},
+		Note: "ng 声母在韩语和普通话中脱落，日语读 g", Example: "語 eo / go"},
+	{ID: "initial_h_rounded", Part: SoundPartInitial, From: []string{"x", "h"}, Before: []string{"w"},
+		Korean: []string{"h"}, Japanese: []string{"k", "g", "w"},
+		Note: "合口的喉音在韩语读 h；日语读 k/g，吴音中也可能变成 w", Example: "話 hwa / wa、化 hwa / ka"},
+	{ID: "initial_h", Part: SoundPartInitial, From: []string{"x", "h"},
+		Korean: []string{"h"}, Japanese: []string{"k", "g"},
+		Note: "喉音在韩语读 h，日语没有 h 音的时代借入，读成 k/g", Example: "學 hak / gaku、現 hyeon / gen"},
+	{ID: "initial_zero", Part: SoundPartInitial, From: []string{"'", ""},
+		Korean: []string{""}, Japanese: []string{""},
+		Note: "零声母在韩语和日语中都以元音开头", Example: "安 an / an"},
+	{ID: "initial_y", Part: SoundPartInitial, From: []string{"y"},
+		Korean: []string{"", "y"}, Japanese: []string{"y", ""},
+		Note: "以母在韩语、日语中读 y 或以元音开头", Example: "用 yong / you"},
+
+	{ID: "final_k", Part: SoundPartFinal, From: []string{"k"},
+		Korean: []string{"k"}, Japanese: []string{"ku", "ki"},
+		Note: "入声韵尾 -k 在韩语保留，日语加上元音成为 -ku/-ki，普通话已经脱落", Example: "學 hak / gaku、國 guk / koku"},
+	{ID: "final_t", Part: SoundPartFinal, From: []string{"t"},
+		Korean: []string{"l"}, Japanese: []string{"tsu", "chi"},
+		Note: "入声韵尾 -t 在韩语变成 -l，日语成为 -tsu/-chi，普通话已经脱落", Example: "發 bal / hatsu"},
+	{ID: "final_p", Part: SoundPartFinal, From: []string{"p"},
+		Korean: []string{"p"}, Japanese: []string{"u", "tsu"},
+		Note: "入声韵尾 -p 在韩语保留，日语古时读 -fu，现在多成为长音 -u，普通话已经脱落", Example: "十 sip / juu、入 ip / nyuu"},
+	{ID: "final_m", Part: SoundPartFinal, From: []string{"m"},
+		Korean: []string{"m"}, Japanese: []string{"n"},
+		Note: "韵尾 -m 在韩语保留，日语和普通话都变成了 -n", Example: "三 sam / san"},
+	{ID: "final_n", Part: SoundPartFinal, From: []string{"n"},
+		Korean: []string{"n"}, Japanese: []string{"n"},
+		Note: "韵尾 -n 在三种语言中都保留了下来", Example: "電 jeon / den、館 gwan / kan"},
+	{ID: "final_ng", Part: SoundPartFinal, From: []string{"ng"},
+		Korean: []string{"ng"}, Japanese: []string{"i", "u"},
+		Note: "韵尾 -ng 在韩语和普通话保留，日语没有这个音，变成长音 -i/-u", Example: "生 saeng / sei、shou"},
+	{ID: "final_w", Part: SoundPartFinal, From: []string{"w"},
+		Korean: []string{"o", "u"}, Japanese: []string{"ou", "uu", "o"},
+		Note: "韵尾 -w 在韩语与元音合并为 o/u，日语成为长音 -ou", Example: "高 go / kou"},
+	{ID: "final_j", Part: SoundPartFinal, From: []string{"j"},
+		Korean: []string{"ae", "e", "oe", "i", "a"}, Japanese: []string{"ai", "ei", "i", "e", "a"},
+		Note: "韵尾 -j 在韩语常与元音合并，日语汉音读 -ai/-ei，吴音读 -e/-a", Example: "大 dae / dai、話 hwa / wa"},
+	{ID: "final_open", Part: SoundPartFinal, From: []string{""},
+		Korean: []string{""}, Japanese: []string{""},
+		Note: "没有韵尾的字在韩语和日语中都以元音结尾", Example: "家 ga / ka、書 seo / sho"},
+}
+
+// middleChineseInitials 白一平转写中的声母，按长度从长到短排列以便最长匹配
+var middleChineseInitials = []string{
+	"tsrh", "tshy",
+	"tsh", "trh", "tsr", "dzr", "tsy", "dzy",
+	"ts", "dz", "tr", "dr", "nr", "sr", "zr", "sy", "zy", "ny", "ph", "th", "kh", "ng",
+	"p", "b", "m", "t", "d", "n", "l", "s", "z", "k", "g", "x", "h", "'", "y",
+}
+
+// middleChineseSyllable 拆分后的中古汉语音节
+type middleChineseSyllable struct {
+	Initial string // 声母，零声母为空字符串
+	Rest    string // 声母之后的部分（韵母），不含声调
+	Final   string // 韵尾：p、t、k、m、n、ng、w、j，没有韵尾为空字符串
+}
+
+// parseMiddleChinese 拆分白一平转写的中古汉语音节，如 "haewk" → h + aewk，韵尾 k；
+// 末尾的 X（上声）和 H（去声）表示声调，忽略
+func parseMiddleChinese(s string) (middleChineseSyllable, bool) {
+	s = strings.TrimRight(strings.TrimSpace(s), "XH")
+	if s == "" {
+		return middleChineseSyllable{}, false
+	}
+
+	var syllable middleChineseSyllable
+	for _, initial := range middleChineseInitials {
+		if strings.HasPrefix(s, initial) {
+			syllable.Initial = initial
+			break
+		}
+	}
+	syllable.Rest = strings.TrimPrefix(s, syllable.Initial)
+	if syllable.Rest == "" || strings.Trim(syllable.Rest, "aeiouy+jwptkmng") != "" {
+		return middleChineseSyllable{}, false
+	}
+
+	switch {
+	case strings.HasSuffix(syllable.Rest, "ng"):
+		syllable.Final = "ng"
+	case len(syllable.Rest) > 1 && strings.ContainsAny(syllable.Rest[len(syllable.Rest)-1:], "ptkmnwj"):
+		syllable.Final = syllable.Rest[len(syllable.Rest)-1:]
+	}
+	return syllable, true
+}
+
+// ReadingPrediction 按读音对应规律推测的某种语言的读音
+type ReadingPrediction struct {
+	Language string      `json:"language"`
+	Source   string      `json:"source"`   // 推测依据：middle_chinese 或 pinyin
+	Initials []string    `json:"initials"` // 可能的声母，空字符串表示零声母
+	Finals   []string    `json:"finals"`   // 可能的结尾，空字符串表示以元音结尾
+	Pattern  string      `json:"pattern"`  // 便于阅读的形式，如 "h…k"、"k/g…ku/ki"
+	Rules    []SoundRule `json:"rules"`    // 推测用到的规律
+}
+
+// PredictReading 根据字根的中古汉语拟音推测它在韩语（ko）或日语音读（ja）中的读音；
+// 没有拟音时根据拼音粗略推测，普通话已经失去入声韵尾，推测的范围会更宽。不支持的语言返回 false
+func PredictReading(root *CharacterRoot, language string) (*ReadingPrediction, bool) {
+	if language != "ko" && language != "ja" {
+		return nil, false
+	}
+
+	prediction := &ReadingPrediction{Language: language, Source: PredictionFromMiddleChinese}
+	syllables := []middleChineseSyllable{}
+	if syllable, ok := parseMiddleChinese(root.MiddleChinese); ok {
+		syllables = append(syllables, syllable)
+	} else {
+		prediction.Source = PredictionFromPinyin
+		syllables = pinyinMiddleChineseCandidates(root.Pinyin)
+	}
+	if len(syllables) == 0 {
+		return nil, false
+	}
+
+	seenRule := make(map[string]bool)
+	for _, syllable := range syllables {
+		for _, rule := range []*SoundRule{matchSoundRule(SoundPartInitial, syllable.Initial, syllable.Rest), matchSoundRule(SoundPartFinal, syllable.Final, "")} {
+			if rule == nil || seenRule[rule.ID] {
+				continue
+			}
+			seenRule[rule.ID] = true
+			prediction.Rules = append(prediction.Rules, *rule)
+			if rule.Part == SoundPartInitial {
+				prediction.Initials = appendUnique(prediction.Initials, rule.forms(language)...)
+			} else {
+				prediction.Finals = appendUnique(prediction.Finals, rule.forms(language)...)
+			}
+		}
+	}
+
+	prediction.Pattern = readingPattern(prediction.Initials, prediction.Finals)
+	return prediction, true
+}
+
+// matchSoundRule 查找适用于中古汉语声母（或韵尾）的第一条规律，rest 是声母之后的部分，用于判断 Before 条件
+func matchSoundRule(part, from, rest string) *SoundRule {
+	for i := range SoundRules {
+		rule := &SoundRules[i]
+		if rule.Part != part || !containsString(rule.From, from) {
+			continue
+		}
+		if len(rule.Before) > 0 && !hasAnyPrefix(rest, rule.Before) {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+// SoundRuleByID 根据ID查找读音对应规律
+func SoundRuleByID(id string) (SoundRule, bool) {
+	for _, rule := range SoundRules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return SoundRule{}, false
+}
+
+// forms 规律在指定语言中的对应形式
+func (r SoundRule) forms(language string) []string {
+	if language == "ko" {
+		return r.Korean
+	}
+	return r.Japanese
+}
+
+// ReadingScore 已知读音与推测的符合程度
+type ReadingScore struct {
+	Language       string      `json:"language"`
+	Reading        string      `json:"reading"`
+	Score          float64     `json:"score"` // 0-1，声母和结尾各占一半
+	InitialMatched bool        `json:"initial_matched"`
+	FinalMatched   bool        `json:"final_matched"`
+	Rules          []SoundRule `json:"rules"`
+	Explanation    string      `json:"explanation"`
+}
+
+// ScoreReading 用读音对应规律检验一个已知读音，如 学 的韩语读音 hak 声母和韵尾都符合规律，得1分
+func ScoreReading(root *CharacterRoot, language, reading string) (*ReadingScore, bool) {
+	prediction, ok := PredictReading(root, language)
+	if !ok {
+		return nil, false
+	}
+
+	reading = normalizeRomaji(reading)
+	score := &ReadingScore{Language: language, Reading: reading, Rules: prediction.Rules}
+	for _, initial := range prediction.Initials {
+		for _, final := range prediction.Finals {
+			if !readingHasInitial(reading, initial) || len(initial)+len(final) > len(reading) {
+				continue
+			}
+			score.InitialMatched = true
+			if readingHasFinal(reading, final) {
+				score.FinalMatched = true
+			}
+		}
+	}
+	if !score.InitialMatched {
+		// 声母不符合时韵尾单独检查
+		for _, final := range prediction.Finals {
+			if readingHasFinal(reading, final) {
+				score.FinalMatched = true
+			}
+		}
+	}
+	if score.InitialMatched {
+		score.Score += 0.5
+	}
+	if score.FinalMatched {
+		score.Score += 0.5
+	}
+	score.Explanation = readingScoreExplanation(root, prediction, score)
+	return score, true
+}
+
+// readingScoreExplanation 逐条说明读音的声母和韵尾是否符合规律
+func readingScoreExplanation(root *CharacterRoot, prediction *ReadingPrediction, score *ReadingScore) string {
+	source := fmt.Sprintf("「%s」的中古汉语拟音是 %s", root.Root, root.MiddleChinese)
+	if prediction.Source == PredictionFromPinyin {
+		source = fmt.Sprintf("「%s」没有收录中古汉语拟音，只能根据拼音 %s 粗略推测", root.Root, root.Pinyin)
+	}
+
+	parts := []string{source}
+	for _, rule := range prediction.Rules {
+		matched := false
+		for _, form := range rule.forms(score.Language) {
+			if rule.Part == SoundPartInitial && readingHasInitial(score.Reading, form) ||
+				rule.Part == SoundPartFinal && readingHasFinal(score.Reading, form) {
+				matched = true
+			}
+		}
+		if !matched && prediction.Source == PredictionFromPinyin {
+			continue // 根据拼音推测时同时列出了多种可能，只说明符合的那些
+		}
+		mark := "✓"
+		if !matched {
+			mark = "✗"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s（%s）", mark, rule.Note, rule.ExampleFor(score.Language)))
+	}
+	return fmt.Sprintf("%s读音 %s：%s", LanguageName(score.Language), score.Reading, strings.Join(parts, "；"))
+}
+
+// readingHasInitial 判断读音是否以指定声母开头，零声母要求以元音或半元音开头
+func readingHasInitial(reading, initial string) bool {
+	if initial == "" {
+		return reading != "" && strings.ContainsRune("aeiouwy", rune(reading[0]))
+	}
+	return strings.HasPrefix(reading, initial) && len(reading) > len(initial) &&
+		!strings.ContainsRune("bcdfghjklmnpqrstvz", rune(reading[len(initial)]))
+}
+
+// readingHasFinal 判断读音是否以指定形式结尾，空字符串要求以元音结尾
+func readingHasFinal(reading, final string) bool {
+	if final == "" {
+		return reading != "" && strings.ContainsRune("aeiou", rune(reading[len(reading)-1]))
+	}
+	if !strings.HasSuffix(reading, final) {
+		return false
+	}
+	// 日语的长音 -i/-u 前面必须是元音，如 sei、kou，gaku 的 u 不算
+	if (final == "i" || final == "u") && len(reading) > 1 {
+		return strings.ContainsRune("aeiou", rune(reading[len(reading)-2]))
+	}
+	return true
+}
+
+// readingPattern 便于阅读的读音形式，如 h…k、k/g…ku/ki，∅ 表示零声母或以元音结尾
+func readingPattern(initials, finals []string) string {
+	show := func(forms []string) string {
+		shown := make([]string, len(forms))
+		for i, form := range forms {
+			shown[i] = form
+			if form == "" {
+				shown[i] = "∅"
+			}
+		}
+		return strings.Join(shown, "/")
+	}
+	return show(initials) + "…" + show(finals)
+}
+
+// pinyinMiddleChineseCandidates 根据拼音反推可能的中古汉语声母和韵尾；普通话的 j、q、x 来自舌根音和齿音的腭化，
+// 以元音结尾的字可能原本是入声，所以一个拼音会对应多种可能
+func pinyinMiddleChineseCandidates(pinyin string) []middleChineseSyllable {
+	p := foldDiacritics(pinyin)
+	if p == "" {
+		return nil
+	}
+
+	var initials []string
+	rest := p
+	for _, mapping := range pinyinInitialMappings {
+		if strings.HasPrefix(p, mapping.pinyin) {
+			initials = mapping.initials
+			rest = strings.TrimPrefix(p, mapping.pinyin)
+			break
+		}
+	}
+	if initials == nil {
+		initials = []string{"'", "ng"}
+	}
+
+	var finals []string
+	switch {
+	case strings.HasSuffix(rest, "ng"):
+		finals = []string{"ng"}
+	case strings.HasSuffix(rest, "n"):
+		finals = []string{"n", "m"}
+	case strings.HasSuffix(rest, "ao"), strings.HasSuffix(rest, "ou"), strings.HasSuffix(rest, "iu"):
+		finals = []string{"w"}
+	default:
+		finals = []string{"", "j", "k", "t", "p"}
+	}
+
+	var result []middleChineseSyllable
+	for _, initial := range initials {
+		for _, final := range finals {
+			result = append(result, middleChineseSyllable{Initial: initial, Rest: rest, Final: final})
+		}
+	}
+	return result
+}
+
+// pinyinInitialMappings 拼音声母可能对应的中古汉语声母，按长度从长到短排列
+var pinyinInitialMappings = []struct {
+	pinyin   string
+	initials []string
+}{
+	{"zh", []string{"tr", "tsr"}},
+	{"ch", []string{"trh", "tsrh", "dzy"}},
+	{"sh", []string{"sr", "sy"}},
+	{"b", []string{"p"}},
+	{"p", []string{"ph"}},
+	{"m", []string{"m"}},
+	{"f", []string{"p"}},
+	{"d", []string{"t"}},
+	{"t", []string{"th"}},
+	{"n", []string{"n"}},
+	{"l", []string{"l"}},
+	{"g", []string{"k"}},
+	{"k", []string{"kh"}},
+	{"h", []string{"x"}},
+	{"j", []string{"k", "ts"}},
+	{"q", []string{"kh", "tsh"}},
+	{"x", []string{"x", "s"}},
+	{"r", []string{"ny"}},
+	{"z", []string{"ts"}},
+	{"c", []string{"tsh"}},
+	{"s", []string{"s"}},
+	{"y", []string{"y", "ng", "'"}},
+	{"w", []string{"m", "ng", "'"}},
+}
+
+// appendUnique 追加不重复的字符串
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		if !containsString(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// hasAnyPrefix 判断字符串是否以其中任意一个前缀开头
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// soundRulesByID 把规律ID展开为完整的规律说明，未知ID忽略；language 不为空时示例只保留该语言的读音
+func soundRulesByID(ids []string, language string) []SoundRule {
+	var rules []SoundRule
+	for _, id := range ids {
+		if rule, ok := SoundRuleByID(id); ok {
+			rule.Example = rule.ExampleFor(language)
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// ExampleFor 只保留示例中某种语言的读音，如 "電 jeon / den" 在韩语（ko）中为 "電 jeon"，在日语（ja）中为 "電 den"；
+// 同一个字在日语中的多种读音跟在后面，如 "文 mun / bun、mon"。language 为空时返回完整示例
+func (r SoundRule) ExampleFor(language string) string {
+	if language != "ko" && language != "ja" {
+		return r.Example
+	}
+
+	var examples []string
+	for _, part := range strings.Split(r.Example, "、") {
+		left, japanese, ok := strings.Cut(part, " / ")
+		if !ok {
+			// 上一个字在日语中的其他读音
+			if language == "ja" && len(examples) > 0 {
+				examples[len(examples)-1] += "、" + part
+			}
+			continue
+		}
+		char, korean, _ := strings.Cut(left, " ")
+		if language == "ko" {
+			examples = append(examples, char+" "+korean)
+		} else {
+			examples = append(examples, char+" "+japanese)
+		}
+	}
+	return strings.Join(examples, "、")
+}
+
+// soundRuleIDs 规律的ID列表
+func soundRuleIDs(rules []SoundRule) []string {
+	ids := make([]string, len(rules))
+	for i, rule := range rules {
+		ids[i] = rule.ID
+	}
+	return ids
+}
+
+// KnownReading 字根在某个词汇中的实际读音
+type KnownReading struct {
+	Language     string `json:"language"`
+	Reading      string `json:"reading"` // 罗马字读音，如 "hak"
+	VocabularyID int64  `json:"vocabulary_id"`
+	Word         string `json:"word"`
+	Hanja        string `json:"hanja,omitempty"` // 韩语词的汉字写法
+}
+
+// KnownReadings 从词汇中找出字根的实际读音：韩语按汉字标注逐音节对齐；日语只取两字音读词，
+// 按推测的声母和韵尾从罗马字中切出字根的音节，切不出来的词不计入。
+// 字根既没有中古汉语拟音也没有拼音时无法用规律检验读音，返回空列表
+func KnownReadings(repo ContentRepository, root *CharacterRoot) ([]KnownReading, error) {
+	prediction, ok := PredictReading(root, "ja")
+	if !ok {
+		return nil, nil
+	}
+
+	vocabs, err := repo.GetVocabulariesByRoot(root.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []KnownReading
+	seen := make(map[string]bool)
+	add := func(vocab Vocabulary, reading string) {
+		if reading == "" || seen[vocab.Language+":"+reading] {
+			return
+		}
+		seen[vocab.Language+":"+reading] = true
+		result = append(result, KnownReading{Language: vocab.Language, Reading: reading, VocabularyID: vocab.ID, Word: vocab.Word, Hanja: vocab.Hanja})
+	}
+
+	for _, vocab := range vocabs {
+		switch vocab.Language {
+		case "ko":
+			for _, s := range vocab.HanjaSyllables() {
+				if s.RootID == root.ID && s.Reading != "" {
+					add(vocab, normalizeRomaji(s.Reading))
+				}
+			}
+		case "ja":
+			if vocab.ReadType != "on" || len([]rune(vocab.Word)) != 2 {
+				continue
+			}
+			for _, r := range vocab.Roots {
+				if r.RootID == root.ID {
+					add(vocab, cutJapaneseReading(normalizeRomaji(vocab.Romaji), r.Position == 0, prediction))
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// cutJapaneseReading 从两字词的罗马字中切出第一个（first）或第二个字的读音，取符合推测的最短音节
+func cutJapaneseReading(romaji string, first bool, prediction *ReadingPrediction) string {
+	for n := 2; n < len(romaji)-1; n++ {
+		candidate := romaji[len(romaji)-n:]
+		if first {
+			candidate = romaji[:n]
+		}
+		if readingMatches(candidate, prediction) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// readingMatches 判断读音的声母和韵尾是否都符合推测
+func readingMatches(reading string, prediction *ReadingPrediction) bool {
+	for _, initial := range prediction.Initials {
+		for _, final := range prediction.Finals {
+			if readingHasInitial(reading, initial) && readingHasFinal(reading, final) &&
+				len(initial)+len(final) <= len(reading) && strings.ContainsAny(reading[len(initial):], "aeiou") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RootReadingReport 字根在韩语、日语音读中的推测读音，以及词汇中实际读音与推测的符合程度
+type RootReadingReport struct {
+	Root        CharacterRoot       `json:"root"`
+	Predictions []ReadingPrediction `json:"predictions"`
+	Readings    []ScoredReading     `json:"readings"`
+}
+
+// ScoredReading 经过读音对应规律检验的实际读音
+type ScoredReading struct {
+	KnownReading
+	Score       float64 `json:"score"`
+	Explanation string  `json:"explanation"`
+}
+
+// AnalyzeRootReadings 推测字根的读音并检验词汇中的实际读音，字根不存在时返回 ErrRootNotFound
+func AnalyzeRootReadings(repo ContentRepository, rootID int64) (*RootReadingReport, error) {
+	root, err := repo.GetRootByID(rootID)
+	if errors.Is(err, ErrContentNotFound) {
+		return nil, ErrRootNotFound.WithDetails(map[string]any{"root_id": rootID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RootReadingReport{Root: *root}
+	for _, language := range soundRuleLanguages {
+		if prediction, ok := PredictReading(root, language); ok {
+			report.Predictions = append(report.Predictions, *prediction)
+		}
+	}
+
+	known, err := KnownReadings(repo, root)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range known {
+		if score, ok := ScoreReading(root, k.Language, k.Reading); ok {
+			report.Readings = append(report.Readings, ScoredReading{KnownReading: k, Score: score.Score, Explanation: score.Explanation})
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,25 @@
+package hanbao
+
+import "testing"
+
+func TestSoundRuleExampleFor(t *testing.T) {
+	tests := []struct {
+		example  string
+		language string
+		want     string
+	}{
+		{"電 jeon / den", "ko", "電 jeon"},
+		{"電 jeon / den", "ja", "電 den"},
+		{"電 jeon / den", "", "電 jeon / den"},
+		{"生 saeng / sei、書 seo / sho", "ko", "生 saeng、書 seo"},
+		{"文 mun / bun、mon", "ko", "文 mun"},
+		{"文 mun / bun、mon", "ja", "文 bun、mon"},
+		{"日 il / nichi、jitsu、十 sip / juu", "ja", "日 nichi、jitsu、十 juu"},
+	}
+	for _, tt := range tests {
+		rule := SoundRule{Example: tt.example}
+		if got := rule.ExampleFor(tt.language); got != tt.want {
+			t.Errorf("ExampleFor(%q) of %q = %q, want %q", tt.language, tt.example, got, tt.want)
+		}
+	}
+}
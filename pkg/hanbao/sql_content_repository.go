@@ -0,0 +1,319 @@
+package hanbao
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/stores/postgres"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	_ "modernc.org/sqlite" // 纯Go实现的SQLite驱动，用于本地开发
+)
+
+// 支持的数据库驱动
+const (
+	DriverSQLite   = "sqlite"
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+)
+
+const (
+	characterRootColumns  = "id, root, pinyin, middle_chinese, traditional, japanese, korean, difficulty, tier, description, created_at, updated_at"
+	vocabularyColumns     = "id, root_id, language, word, romaji, hanja, chinese, morphemes, pronunciation, meaning, read_type, difficulty, example_count, created_at, updated_at"
+	dialectExampleColumns = "id, root_id, standard, dialect, dialect_type, description, audio_url"
+)
+
+// NewSQLConn 根据驱动名称创建数据库连接
+func NewSQLConn(driver, dataSource string) (sqlx.SqlConn, error) {
+	switch driver {
+	case DriverSQLite:
+		return sqlx.NewSqlConn(DriverSQLite, dataSource), nil
+	case DriverMySQL:
+		return sqlx.NewMysql(dataSource), nil
+	case DriverPostgres:
+		return postgres.New(dataSource), nil
+	default:
+		return nil, fmt.Errorf("不支持的数据库驱动: %s", driver)
+	}
+}
+
+// SQLContentRepository 基于SQL数据库的内容仓库
+type SQLContentRepository struct {
+	conn   sqlx.SqlConn
+	driver string
+}
+
+// NewSQLContentRepository 创建SQL内容仓库
+func NewSQLContentRepository(conn sqlx.SqlConn, driver string) *SQLContentRepository {
+	return &SQLContentRepository{
+		conn:   conn,
+		driver: driver,
+	}
+}
+
+// Seed 将内容包同步到数据库，可以在每次启动时执行：数据库中没有的记录（按ID）写入；
+// 已有的记录只补齐后续迁移新增、仍为空的字段（中古音、异体字、汉字词、中文词和词素）以及缺少的组成字根，
+// 不覆盖在线修改过的内容
+func (r *SQLContentRepository) Seed(roots []CharacterRoot, vocabularies []Vocabulary, dialectExamples []DialectExample) error {
+	return r.conn.Transact(func(session sqlx.Session) error {
+		rootIDs, err := existingIDs(session, "character_roots")
+		if err != nil {
+			return err
+		}
+		vocabularyIDs, err := existingIDs(session, "vocabularies")
+		if err != nil {
+			return err
+		}
+		exampleIDs, err := existingIDs(session, "dialect_examples")
+		if err != nil {
+			return err
+		}
+		var componentRows []vocabularyRootRow
+		if err := session.QueryRows(&componentRows, "SELECT vocabulary_id, root_id, position FROM vocabulary_roots"); err != nil {
+			return err
+		}
+		components := make(map[[2]int64]bool, len(componentRows))
+		for _, row := range componentRows {
+			components[[2]int64{row.VocabularyID, row.RootID}] = true
+		}
+
+		for _, root := range roots {
+			if rootIDs[root.ID] {
+				if _, err := session.Exec(r.rebind(`UPDATE character_roots SET
+					middle_chinese = CASE WHEN middle_chinese = '' THEN ? ELSE middle_chinese END,
+					traditional = CASE WHEN traditional = '' THEN ? ELSE traditional END,
+					japanese = CASE WHEN japanese = '' THEN ? ELSE japanese END,
+					korean = CASE WHEN korean = '' THEN ? ELSE korean END
+					WHERE id = ?`),
+					root.MiddleChinese, root.Traditional, root.Japanese, root.Korean, root.ID); err != nil {
+					return fmt.Errorf("补齐字根 %d 失败: %w", root.ID, err)
+				}
+				continue
+			}
+			if _, err := session.Exec(r.rebind(`INSERT INTO character_roots (`+characterRootColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				root.ID, root.Root, root.Pinyin, root.MiddleChinese, root.Traditional, root.Japanese, root.Korean, root.Difficulty, root.Tier, root.Description,
+				root.CreatedAt, root.UpdatedAt); err != nil {
+				return fmt.Errorf("写入字根 %d 失败: %w", root.ID, err)
+			}
+		}
+
+		for _, vocab := range vocabularies {
+			if vocabularyIDs[vocab.ID] {
+				if _, err := session.Exec(r.rebind(`UPDATE vocabularies SET
+					hanja = CASE WHEN hanja = '' THEN ? ELSE hanja END,
+					chinese = CASE WHEN chinese = '' THEN ? ELSE chinese END,
+					morphemes = CASE WHEN morphemes = '' THEN ? ELSE morphemes END
+					WHERE id = ?`),
+					vocab.Hanja, vocab.Chinese, vocab.Morphemes, vocab.ID); err != nil {
+					return fmt.Errorf("补齐词汇 %d 失败: %w", vocab.ID, err)
+				}
+			} else if _, err := session.Exec(r.rebind(`INSERT INTO vocabularies (`+vocabularyColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				vocab.ID, vocab.RootID, vocab.Language, vocab.Word, vocab.Romaji, vocab.Hanja, vocab.Chinese, vocab.Morphemes,
+				vocab.Pronunciation, vocab.Meaning, vocab.ReadType, vocab.Difficulty, vocab.ExampleCount,
+				vocab.CreatedAt, vocab.UpdatedAt); err != nil {
+				return fmt.Errorf("写入词汇 %d 失败: %w", vocab.ID, err)
+			}
+
+			for _, component := range vocabularyComponents(vocab) {
+				if components[[2]int64{vocab.ID, component.RootID}] {
+					continue
+				}
+				if _, err := session.Exec(r.rebind(`INSERT INTO vocabulary_roots (vocabulary_id, root_id, position)
+					VALUES (?, ?, ?)`), vocab.ID, component.RootID, component.Position); err != nil {
+					return fmt.Errorf("写入词汇 %d 的组成字根失败: %w", vocab.ID, err)
+				}
+			}
+		}
+
+		for _, example := range dialectExamples {
+			if exampleIDs[example.ID] {
+				continue
+			}
+			if _, err := session.Exec(r.rebind(`INSERT INTO dialect_examples (`+dialectExampleColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`),
+				example.ID, example.RootID, example.Standard, example.Dialect, example.DialectType,
+				example.Description, example.AudioURL); err != nil {
+				return fmt.Errorf("写入方言示例 %d 失败: %w", example.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// existingIDs 查询表中已有记录的ID
+func existingIDs(session sqlx.Session, table string) (map[int64]bool, error) {
+	var ids []int64
+	if err := session.QueryRows(&ids, "SELECT id FROM "+table); err != nil {
+		return nil, err
+	}
+	result := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
+
+// ListRoots 获取所有字根
+func (r *SQLContentRepository) ListRoots() ([]CharacterRoot, error) {
+	var roots []CharacterRoot
+	err := r.conn.QueryRows(&roots, "SELECT "+characterRootColumns+" FROM character_roots ORDER BY id")
+	return roots, err
+}
+
+// GetRootByID 根据ID获取字根
+func (r *SQLContentRepository) GetRootByID(rootID int64) (*CharacterRoot, error) {
+	var root CharacterRoot
+	err := r.conn.QueryRow(&root, r.rebind("SELECT "+characterRootColumns+" FROM character_roots WHERE id = ?"), rootID)
+	if err != nil {
+		return nil, r.translateError(err)
+	}
+	return &root, nil
+}
+
+// GetRootByChar 根据字根汉字获取字根，繁体字、日本新字体和韩国汉字也能匹配，简体字优先
+func (r *SQLContentRepository) GetRootByChar(char string) (*CharacterRoot, error) {
+	var root CharacterRoot
+	err := r.conn.QueryRow(&root, r.rebind("SELECT "+characterRootColumns+` FROM character_roots
+		WHERE root = ? OR traditional = ? OR japanese = ? OR korean = ?
+		ORDER BY CASE WHEN root = ? THEN 0 ELSE 1 END, id LIMIT 1`), char, char, char, char, char)
+	if err != nil {
+		return nil, r.translateError(err)
+	}
+	return &root, nil
+}
+
+// ListVocabularies 获取所有词汇
+func (r *SQLContentRepository) ListVocabularies() ([]Vocabulary, error) {
+	var vocabs []Vocabulary
+	if err := r.conn.QueryRows(&vocabs, "SELECT "+vocabularyColumns+" FROM vocabularies ORDER BY id"); err != nil {
+		return nil, err
+	}
+	return r.attachRoots(vocabs, "SELECT vocabulary_id, root_id, position FROM vocabulary_roots")
+}
+
+// GetVocabularyByID 根据ID获取词汇
+func (r *SQLContentRepository) GetVocabularyByID(vocabularyID int64) (*Vocabulary, error) {
+	var vocab Vocabulary
+	err := r.conn.QueryRow(&vocab, r.rebind("SELECT "+vocabularyColumns+" FROM vocabularies WHERE id = ?"), vocabularyID)
+	if err != nil {
+		return nil, r.translateError(err)
+	}
+
+	vocabs, err := r.attachRoots([]Vocabulary{vocab}, r.rebind(`SELECT vocabulary_id, root_id, position FROM vocabulary_roots
+		WHERE vocabulary_id = ?`), vocabularyID)
+	if err != nil {
+		return nil, err
+	}
+	return &vocabs[0], nil
+}
+
+// GetVocabulariesByRoot 获取包含指定字根的所有词汇（含复合词）
+func (r *SQLContentRepository) GetVocabulariesByRoot(rootID int64) ([]Vocabulary, error) {
+	var vocabs []Vocabulary
+	if err := r.conn.QueryRows(&vocabs, r.rebind(`SELECT `+vocabularyColumns+` FROM vocabularies
+		WHERE id IN (SELECT vocabulary_id FROM vocabulary_roots WHERE root_id = ?) ORDER BY id`), rootID); err != nil {
+		return nil, err
+	}
+	return r.attachRoots(vocabs, r.rebind(`SELECT vocabulary_id, root_id, position FROM vocabulary_roots
+		WHERE vocabulary_id IN (SELECT vocabulary_id FROM vocabulary_roots WHERE root_id = ?)`), rootID)
+}
+
+// GetVocabulariesByLanguage 获取指定语言的所有词汇
+func (r *SQLContentRepository) GetVocabulariesByLanguage(language string) ([]Vocabulary, error) {
+	var vocabs []Vocabulary
+	if err := r.conn.QueryRows(&vocabs, r.rebind("SELECT "+vocabularyColumns+" FROM vocabularies WHERE language = ? ORDER BY id"), language); err != nil {
+		return nil, err
+	}
+	return r.attachRoots(vocabs, r.rebind(`SELECT vocabulary_id, root_id, position FROM vocabulary_roots
+		WHERE vocabulary_id IN (SELECT id FROM vocabularies WHERE language = ?)`), language)
+}
+
+// vocabularyRootRow vocabulary_roots 表的一行
+type vocabularyRootRow struct {
+	VocabularyID int64 `db:"vocabulary_id"`
+	RootID       int64 `db:"root_id"`
+	Position     int   `db:"position"`
+}
+
+// attachRoots 查询组成字根并按位置顺序填充到词汇中，同时由谚文生成韩语逐音节读音（不保存在数据库中）
+func (r *SQLContentRepository) attachRoots(vocabs []Vocabulary, query string, args ...any) ([]Vocabulary, error) {
+	if len(vocabs) == 0 {
+		return vocabs, nil
+	}
+
+	var rows []vocabularyRootRow
+	if err := r.conn.QueryRows(&rows, query+" ORDER BY vocabulary_id, position", args...); err != nil {
+		return nil, err
+	}
+
+	components := make(map[int64][]VocabularyRoot)
+	for _, row := range rows {
+		components[row.VocabularyID] = append(components[row.VocabularyID], VocabularyRoot{
+			RootID:   row.RootID,
+			Position: row.Position,
+		})
+	}
+	for i := range vocabs {
+		vocabs[i].Roots = components[vocabs[i].ID]
+	}
+
+	return fillVocabularyRomanization(vocabs), nil
+}
+
+// ListDialectExamples 获取所有方言示例
+func (r *SQLContentRepository) ListDialectExamples() ([]DialectExample, error) {
+	var examples []DialectExample
+	err := r.conn.QueryRows(&examples, "SELECT "+dialectExampleColumns+" FROM dialect_examples ORDER BY id")
+	return examples, err
+}
+
+// GetDialectExamplesByRoot 获取指定字根的方言示例
+func (r *SQLContentRepository) GetDialectExamplesByRoot(rootID int64) ([]DialectExample, error) {
+	var examples []DialectExample
+	err := r.conn.QueryRows(&examples, r.rebind("SELECT "+dialectExampleColumns+" FROM dialect_examples WHERE root_id = ? ORDER BY id"), rootID)
+	return examples, err
+}
+
+// vocabularyComponents 返回词汇的组成字根，未填写时使用主字根
+func vocabularyComponents(vocab Vocabulary) []VocabularyRoot {
+	if len(vocab.Roots) > 0 {
+		return vocab.Roots
+	}
+	return []VocabularyRoot{{RootID: vocab.RootID}}
+}
+
+// translateError 将数据库错误转换为仓库错误
+func (r *SQLContentRepository) translateError(err error) error {
+	if errors.Is(err, sqlx.ErrNotFound) {
+		return ErrContentNotFound
+	}
+	return err
+}
+
+// rebind 将 ? 占位符转换为驱动所需的格式
+func (r *SQLContentRepository) rebind(query string) string {
+	return rebindQuery(r.driver, query)
+}
+
+// rebindQuery 将 ? 占位符转换为驱动所需的格式，Postgres 使用 $1, $2...
+func rebindQuery(driver, query string) string {
+	if driver != DriverPostgres {
+		return query
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
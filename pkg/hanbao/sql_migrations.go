@@ -0,0 +1,213 @@
+package hanbao
+
+import (
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// sqlMigration 数据库迁移
+type sqlMigration struct {
+	Version    int
+	Name       string
+	Statements []string
+}
+
+// sqlMigrations 数据库迁移列表，只允许追加，不要修改已发布的版本
+var sqlMigrations = []sqlMigration{
+	{
+		Version: 1,
+		Name:    "create_content_tables",
+		Statements: []string{
+			`CREATE TABLE character_roots (
+				id BIGINT NOT NULL PRIMARY KEY,
+				root VARCHAR(16) NOT NULL,
+				pinyin VARCHAR(64) NOT NULL DEFAULT '',
+				difficulty INT NOT NULL DEFAULT 1,
+				tier INT NOT NULL DEFAULT 1,
+				description VARCHAR(255) NOT NULL DEFAULT '',
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX idx_character_roots_root ON character_roots (root)`,
+			`CREATE TABLE vocabularies (
+				id BIGINT NOT NULL PRIMARY KEY,
+				root_id BIGINT NOT NULL,
+				language VARCHAR(8) NOT NULL,
+				word VARCHAR(64) NOT NULL,
+				romaji VARCHAR(128) NOT NULL DEFAULT '',
+				pronunciation VARCHAR(128) NOT NULL DEFAULT '',
+				meaning VARCHAR(255) NOT NULL DEFAULT '',
+				read_type VARCHAR(8) NOT NULL DEFAULT '',
+				difficulty INT NOT NULL DEFAULT 1,
+				example_count INT NOT NULL DEFAULT 0,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX idx_vocabularies_root_id ON vocabularies (root_id)`,
+			`CREATE INDEX idx_vocabularies_language ON vocabularies (language)`,
+			`CREATE TABLE dialect_examples (
+				id BIGINT NOT NULL PRIMARY KEY,
+				root_id BIGINT NOT NULL,
+				standard VARCHAR(64) NOT NULL,
+				dialect VARCHAR(64) NOT NULL,
+				dialect_type VARCHAR(32) NOT NULL,
+				description VARCHAR(255) NOT NULL DEFAULT '',
+				audio_url VARCHAR(512) NOT NULL DEFAULT ''
+			)`,
+			`CREATE INDEX idx_dialect_examples_root_id ON dialect_examples (root_id)`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_vocabulary_roots",
+		Statements: []string{
+			`CREATE TABLE vocabulary_roots (
+				vocabulary_id BIGINT NOT NULL,
+				root_id BIGINT NOT NULL,
+				position INT NOT NULL DEFAULT 0,
+				PRIMARY KEY (vocabulary_id, root_id)
+			)`,
+			`CREATE INDEX idx_vocabulary_roots_root_id ON vocabulary_roots (root_id)`,
+			// 已有词汇只知道主字根，位置默认为0
+			`INSERT INTO vocabulary_roots (vocabulary_id, root_id, position) SELECT id, root_id, 0 FROM vocabularies`,
+		},
+	},
+	{
+		Version: 3,
+		Name:    "create_user_sessions",
+		Statements: []string{
+			`CREATE TABLE user_sessions (
+				id VARCHAR(64) NOT NULL PRIMARY KEY,
+				user_id VARCHAR(64) NOT NULL DEFAULT '',
+				unlocked_roots TEXT NOT NULL,
+				completed_levels TEXT NOT NULL,
+				score INT NOT NULL DEFAULT 0,
+				accuracy DOUBLE PRECISION NOT NULL DEFAULT 0,
+				total_answers INT NOT NULL DEFAULT 0,
+				correct_answers INT NOT NULL DEFAULT 0,
+				start_time TIMESTAMP NOT NULL,
+				last_active TIMESTAMP NOT NULL,
+				status VARCHAR(16) NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX idx_user_sessions_user_id ON user_sessions (user_id)`,
+		},
+	},
+	{
+		Version: 4,
+		Name:    "create_review_cards",
+		Statements: []string{
+			`CREATE TABLE review_cards (
+				user_id VARCHAR(64) NOT NULL,
+				vocabulary_id BIGINT NOT NULL,
+				stability DOUBLE PRECISION NOT NULL DEFAULT 0,
+				difficulty DOUBLE PRECISION NOT NULL DEFAULT 0,
+				reps INT NOT NULL DEFAULT 0,
+				lapses INT NOT NULL DEFAULT 0,
+				due TIMESTAMP NOT NULL,
+				last_review TIMESTAMP NULL,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (user_id, vocabulary_id)
+			)`,
+			`CREATE INDEX idx_review_cards_user_due ON review_cards (user_id, due)`,
+		},
+	},
+	{
+		Version: 5,
+		Name:    "create_skill_ratings",
+		Statements: []string{
+			`CREATE TABLE skill_ratings (
+				subject VARCHAR(191) NOT NULL PRIMARY KEY,
+				rating DOUBLE PRECISION NOT NULL,
+				attempts INT NOT NULL DEFAULT 0,
+				updated_at TIMESTAMP NOT NULL
+			)`,
+		},
+	},
+	{
+		Version: 6,
+		Name:    "add_vocabulary_hanja",
+		Statements: []string{
+			`ALTER TABLE vocabularies ADD COLUMN hanja VARCHAR(64) NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Version: 7,
+		Name:    "add_vocabulary_chinese",
+		Statements: []string{
+			`ALTER TABLE vocabularies ADD COLUMN chinese VARCHAR(64) NOT NULL DEFAULT ''`,
+			`CREATE INDEX idx_vocabularies_chinese ON vocabularies (chinese)`,
+		},
+	},
+	{
+		Version: 8,
+		Name:    "add_vocabulary_morphemes",
+		Statements: []string{
+			`ALTER TABLE vocabularies ADD COLUMN morphemes VARCHAR(255) NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Version: 9,
+		Name:    "add_root_middle_chinese",
+		Statements: []string{
+			`ALTER TABLE character_roots ADD COLUMN middle_chinese VARCHAR(32) NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Version: 10,
+		Name:    "add_root_variants",
+		Statements: []string{
+			`ALTER TABLE character_roots ADD COLUMN traditional VARCHAR(8) NOT NULL DEFAULT ''`,
+			`ALTER TABLE character_roots ADD COLUMN japanese VARCHAR(8) NOT NULL DEFAULT ''`,
+			`ALTER TABLE character_roots ADD COLUMN korean VARCHAR(8) NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Version: 11,
+		Name:    "add_session_answered_questions",
+		Statements: []string{
+			// MySQL 的 TEXT 列不能设置默认值，已有会话读取为空列表
+			`ALTER TABLE user_sessions ADD COLUMN answered_questions TEXT NULL`,
+		},
+	},
+}
+
+// MigrateSQL 依次执行版本号大于当前版本的迁移
+func MigrateSQL(conn sqlx.SqlConn, driver string) error {
+	if _, err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT NOT NULL PRIMARY KEY,
+		name VARCHAR(128) NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("创建迁移表失败: %w", err)
+	}
+
+	var current int
+	if err := conn.QueryRow(&current, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations"); err != nil {
+		return fmt.Errorf("读取迁移版本失败: %w", err)
+	}
+
+	for _, m := range sqlMigrations {
+		if m.Version <= current {
+			continue
+		}
+
+		err := conn.Transact(func(session sqlx.Session) error {
+			for _, stmt := range m.Statements {
+				if _, err := session.Exec(stmt); err != nil {
+					return err
+				}
+			}
+			_, err := session.Exec(rebindQuery(driver, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)"),
+				m.Version, m.Name)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("执行迁移 %d_%s 失败: %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package hanbao
+
+import (
+	"errors"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// SQLRatingStore 基于SQL数据库的能力评分存储
+type SQLRatingStore struct {
+	conn   sqlx.SqlConn
+	driver string
+}
+
+// NewSQLRatingStore 创建SQL能力评分存储
+func NewSQLRatingStore(conn sqlx.SqlConn, driver string) *SQLRatingStore {
+	return &SQLRatingStore{
+		conn:   conn,
+		driver: driver,
+	}
+}
+
+// Get 获取评分
+func (s *SQLRatingStore) Get(subject string) (*SkillRating, bool, error) {
+	var rating SkillRating
+	err := s.conn.QueryRow(&rating, rebindQuery(s.driver,
+		"SELECT subject, rating, attempts, updated_at FROM skill_ratings WHERE subject = ?"), subject)
+	if errors.Is(err, sqlx.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &rating, true, nil
+}
+
+// Save 保存评分
+func (s *SQLRatingStore) Save(rating *SkillRating) error {
+	return s.conn.Transact(func(tx sqlx.Session) error {
+		var count int64
+		if err := tx.QueryRow(&count, rebindQuery(s.driver,
+			"SELECT COUNT(*) FROM skill_ratings WHERE subject = ?"), rating.Subject); err != nil {
+			return err
+		}
+
+		if count == 0 {
+			_, err := tx.Exec(rebindQuery(s.driver,
+				"INSERT INTO skill_ratings (subject, rating, attempts, updated_at) VALUES (?, ?, ?, ?)"),
+				rating.Subject, rating.Rating, rating.Attempts, rating.UpdatedAt)
+			return err
+		}
+
+		_, err := tx.Exec(rebindQuery(s.driver,
+			"UPDATE skill_ratings SET rating = ?, attempts = ?, updated_at = ? WHERE subject = ?"),
+			rating.Rating, rating.Attempts, rating.UpdatedAt, rating.Subject)
+		return err
+	})
+}
@@ -0,0 +1,134 @@
+package hanbao
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const reviewCardColumns = "user_id, vocabulary_id, stability, difficulty, reps, lapses, due, last_review, created_at, updated_at"
+
+// reviewCardRow review_cards 表的一行，新卡片的 last_review 为 NULL
+type reviewCardRow struct {
+	UserID       string       `db:"user_id"`
+	VocabularyID int64        `db:"vocabulary_id"`
+	Stability    float64      `db:"stability"`
+	Difficulty   float64      `db:"difficulty"`
+	Reps         int          `db:"reps"`
+	Lapses       int          `db:"lapses"`
+	Due          time.Time    `db:"due"`
+	LastReview   sql.NullTime `db:"last_review"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+}
+
+// card 转换为复习卡片
+func (r reviewCardRow) card() ReviewCard {
+	return ReviewCard{
+		UserID:       r.UserID,
+		VocabularyID: r.VocabularyID,
+		Stability:    r.Stability,
+		Difficulty:   r.Difficulty,
+		Reps:         r.Reps,
+		Lapses:       r.Lapses,
+		Due:          r.Due,
+		LastReview:   r.LastReview.Time,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
+
+// SQLReviewStore 基于SQL数据库的复习卡片存储
+type SQLReviewStore struct {
+	conn   sqlx.SqlConn
+	driver string
+}
+
+// NewSQLReviewStore 创建SQL复习卡片存储
+func NewSQLReviewStore(conn sqlx.SqlConn, driver string) *SQLReviewStore {
+	return &SQLReviewStore{
+		conn:   conn,
+		driver: driver,
+	}
+}
+
+// Get 获取卡片
+func (s *SQLReviewStore) Get(userID string, vocabularyID int64) (*ReviewCard, error) {
+	var row reviewCardRow
+	err := s.conn.QueryRow(&row, rebindQuery(s.driver, "SELECT "+reviewCardColumns+
+		" FROM review_cards WHERE user_id = ? AND vocabulary_id = ?"), userID, vocabularyID)
+	if errors.Is(err, sqlx.ErrNotFound) {
+		return nil, ErrReviewCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	card := row.card()
+	return &card, nil
+}
+
+// Save 保存卡片
+func (s *SQLReviewStore) Save(card *ReviewCard) error {
+	lastReview := sql.NullTime{Time: card.LastReview, Valid: !card.LastReview.IsZero()}
+
+	return s.conn.Transact(func(tx sqlx.Session) error {
+		var count int64
+		if err := tx.QueryRow(&count, rebindQuery(s.driver,
+			"SELECT COUNT(*) FROM review_cards WHERE user_id = ? AND vocabulary_id = ?"),
+			card.UserID, card.VocabularyID); err != nil {
+			return err
+		}
+
+		if count == 0 {
+			_, err := tx.Exec(rebindQuery(s.driver, `INSERT INTO review_cards (`+reviewCardColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				card.UserID, card.VocabularyID, card.Stability, card.Difficulty, card.Reps, card.Lapses,
+				card.Due, lastReview, card.CreatedAt, card.UpdatedAt)
+			return err
+		}
+
+		_, err := tx.Exec(rebindQuery(s.driver, `UPDATE review_cards SET stability = ?, difficulty = ?, reps = ?,
+			lapses = ?, due = ?, last_review = ?, updated_at = ? WHERE user_id = ? AND vocabulary_id = ?`),
+			card.Stability, card.Difficulty, card.Reps, card.Lapses, card.Due, lastReview, card.UpdatedAt,
+			card.UserID, card.VocabularyID)
+		return err
+	})
+}
+
+// List 获取学习者的所有卡片
+func (s *SQLReviewStore) List(userID string) ([]ReviewCard, error) {
+	var rows []reviewCardRow
+	if err := s.conn.QueryRows(&rows, rebindQuery(s.driver, "SELECT "+reviewCardColumns+
+		" FROM review_cards WHERE user_id = ? ORDER BY due, vocabulary_id"), userID); err != nil {
+		return nil, err
+	}
+	return reviewCards(rows), nil
+}
+
+// ListDue 获取到期的卡片
+func (s *SQLReviewStore) ListDue(userID string, before time.Time, limit int) ([]ReviewCard, error) {
+	query := "SELECT " + reviewCardColumns + " FROM review_cards WHERE user_id = ? AND due <= ? ORDER BY due, vocabulary_id"
+	args := []any{userID, before}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	var rows []reviewCardRow
+	if err := s.conn.QueryRows(&rows, rebindQuery(s.driver, query), args...); err != nil {
+		return nil, err
+	}
+	return reviewCards(rows), nil
+}
+
+// reviewCards 将数据库行转换为复习卡片
+func reviewCards(rows []reviewCardRow) []ReviewCard {
+	cards := make([]ReviewCard, len(rows))
+	for i, row := range rows {
+		cards[i] = row.card()
+	}
+	return cards
+}
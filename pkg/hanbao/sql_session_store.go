@@ -0,0 +1,137 @@
+package hanbao
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const userSessionColumns = "id, user_id, unlocked_roots, completed_levels, answered_questions, score, accuracy, total_answers, correct_answers, start_time, last_active, status, created_at, updated_at"
+
+// userSessionRow user_sessions 表的一行，列表字段以JSON保存
+type userSessionRow struct {
+	ID                string         `db:"id"`
+	UserID            string         `db:"user_id"`
+	UnlockedRoots     string         `db:"unlocked_roots"`
+	CompletedLevels   string         `db:"completed_levels"`
+	AnsweredQuestions sql.NullString `db:"answered_questions"`
+	Score             int            `db:"score"`
+	Accuracy          float64        `db:"accuracy"`
+	TotalAnswers      int            `db:"total_answers"`
+	CorrectAnswers    int            `db:"correct_answers"`
+	StartTime         time.Time      `db:"start_time"`
+	LastActive        time.Time      `db:"last_active"`
+	Status            string         `db:"status"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+// SQLSessionStore 基于SQL数据库的会话存储
+type SQLSessionStore struct {
+	conn   sqlx.SqlConn
+	driver string
+	expire time.Duration
+}
+
+// NewSQLSessionStore 创建SQL会话存储，超过 expire 未活跃的会话视为过期
+func NewSQLSessionStore(conn sqlx.SqlConn, driver string, expire time.Duration) *SQLSessionStore {
+	return &SQLSessionStore{
+		conn:   conn,
+		driver: driver,
+		expire: expire,
+	}
+}
+
+// Get 获取会话
+func (s *SQLSessionStore) Get(sessionID string) (*UserSession, error) {
+	var row userSessionRow
+	err := s.conn.QueryRow(&row, rebindQuery(s.driver, "SELECT "+userSessionColumns+" FROM user_sessions WHERE id = ?"), sessionID)
+	if errors.Is(err, sqlx.ErrNotFound) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.expire > 0 && time.Since(row.LastActive) > s.expire {
+		return nil, ErrSessionNotFound
+	}
+
+	session := &UserSession{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		Score:          row.Score,
+		Accuracy:       row.Accuracy,
+		TotalAnswers:   row.TotalAnswers,
+		CorrectAnswers: row.CorrectAnswers,
+		StartTime:      row.StartTime,
+		LastActive:     row.LastActive,
+		Status:         row.Status,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(row.UnlockedRoots), &session.UnlockedRoots); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(row.CompletedLevels), &session.CompletedLevels); err != nil {
+		return nil, err
+	}
+	if row.AnsweredQuestions.Valid {
+		if err := json.Unmarshal([]byte(row.AnsweredQuestions.String), &session.AnsweredQuestions); err != nil {
+			return nil, err
+		}
+	}
+
+	return session, nil
+}
+
+// Save 保存会话
+func (s *SQLSessionStore) Save(session *UserSession) error {
+	unlockedRoots, err := json.Marshal(nonNil(session.UnlockedRoots))
+	if err != nil {
+		return err
+	}
+	completedLevels, err := json.Marshal(nonNil(session.CompletedLevels))
+	if err != nil {
+		return err
+	}
+	answeredQuestions, err := json.Marshal(nonNil(session.AnsweredQuestions))
+	if err != nil {
+		return err
+	}
+
+	return s.conn.Transact(func(tx sqlx.Session) error {
+		var count int64
+		if err := tx.QueryRow(&count, rebindQuery(s.driver, "SELECT COUNT(*) FROM user_sessions WHERE id = ?"), session.ID); err != nil {
+			return err
+		}
+
+		if count == 0 {
+			_, err := tx.Exec(rebindQuery(s.driver, `INSERT INTO user_sessions (`+userSessionColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				session.ID, session.UserID, string(unlockedRoots), string(completedLevels), string(answeredQuestions), session.Score,
+				session.Accuracy, session.TotalAnswers, session.CorrectAnswers, session.StartTime,
+				session.LastActive, session.Status, session.CreatedAt, session.UpdatedAt)
+			return err
+		}
+
+		_, err := tx.Exec(rebindQuery(s.driver, `UPDATE user_sessions SET user_id = ?, unlocked_roots = ?,
+			completed_levels = ?, answered_questions = ?, score = ?, accuracy = ?, total_answers = ?,
+			correct_answers = ?, last_active = ?, status = ?, updated_at = ? WHERE id = ?`),
+			session.UserID, string(unlockedRoots), string(completedLevels), string(answeredQuestions), session.Score, session.Accuracy,
+			session.TotalAnswers, session.CorrectAnswers, session.LastActive, session.Status,
+			session.UpdatedAt, session.ID)
+		return err
+	})
+}
+
+// nonNil 将 nil 切片转为空切片，保证序列化为 [] 而不是 null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
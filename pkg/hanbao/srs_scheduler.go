@@ -0,0 +1,142 @@
+package hanbao
+
+import (
+	"math"
+	"time"
+)
+
+// ReviewGrade 复习评分
+type ReviewGrade int
+
+// 复习评分，与 FSRS 的四档评分一致
+const (
+	GradeAgain ReviewGrade = 1 // 忘记了
+	GradeHard  ReviewGrade = 2 // 想起来但很吃力
+	GradeGood  ReviewGrade = 3 // 正常想起
+	GradeEasy  ReviewGrade = 4 // 轻松想起
+)
+
+// Valid 判断评分是否在 1-4 之间
+func (g ReviewGrade) Valid() bool {
+	return g >= GradeAgain && g <= GradeEasy
+}
+
+const (
+	fsrsDecay = -0.5
+	// relearnDelay 忘记后重新学习的等待时间
+	relearnDelay = 10 * time.Minute
+)
+
+// fsrsFactor 使间隔等于稳定性时保持率为90%的系数，即 19/81
+var fsrsFactor = math.Pow(0.9, 1/fsrsDecay) - 1
+
+// DefaultFSRSWeights FSRS-4.5 的默认参数
+var DefaultFSRSWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031, 1.6474,
+	0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// FSRS 基于 FSRS-4.5 算法的复习调度器，根据评分更新词汇的记忆稳定性和难度
+type FSRS struct {
+	Weights          [17]float64
+	DesiredRetention float64 // 期望的记忆保持率，越高复习越频繁
+	MaximumInterval  int     // 最长复习间隔（天）
+}
+
+// NewFSRS 使用默认参数创建调度器
+func NewFSRS(desiredRetention float64, maximumInterval int) *FSRS {
+	return &FSRS{
+		Weights:          DefaultFSRSWeights,
+		DesiredRetention: desiredRetention,
+		MaximumInterval:  maximumInterval,
+	}
+}
+
+// Schedule 根据评分更新卡片的记忆状态和下次复习时间
+func (f *FSRS) Schedule(card *ReviewCard, grade ReviewGrade, now time.Time) {
+	if card.Reps == 0 {
+		card.Stability = f.initStability(grade)
+		card.Difficulty = f.initDifficulty(grade)
+	} else {
+		elapsedDays := math.Max(0, now.Sub(card.LastReview).Hours()/24)
+		r := f.Retrievability(elapsedDays, card.Stability)
+		if grade == GradeAgain {
+			card.Stability = f.forgetStability(card.Difficulty, card.Stability, r)
+			card.Lapses++
+		} else {
+			card.Stability = f.recallStability(card.Difficulty, card.Stability, r, grade)
+		}
+		card.Difficulty = f.nextDifficulty(card.Difficulty, grade)
+	}
+
+	card.Reps++
+	card.LastReview = now
+	if grade == GradeAgain {
+		card.Due = now.Add(relearnDelay)
+	} else {
+		card.Due = now.AddDate(0, 0, f.NextInterval(card.Stability))
+	}
+}
+
+// Retrievability 经过 elapsedDays 天后的回忆概率
+func (f *FSRS) Retrievability(elapsedDays, stability float64) float64 {
+	if stability <= 0 {
+		return 0
+	}
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+// NextInterval 根据稳定性计算保持率降到期望值所需的天数
+func (f *FSRS) NextInterval(stability float64) int {
+	interval := stability / fsrsFactor * (math.Pow(f.DesiredRetention, 1/fsrsDecay) - 1)
+	days := int(math.Round(interval))
+	if days < 1 {
+		days = 1
+	}
+	if f.MaximumInterval > 0 && days > f.MaximumInterval {
+		days = f.MaximumInterval
+	}
+	return days
+}
+
+// initStability 首次评分后的稳定性
+func (f *FSRS) initStability(grade ReviewGrade) float64 {
+	return math.Max(f.Weights[grade-1], 0.1)
+}
+
+// initDifficulty 首次评分后的难度
+func (f *FSRS) initDifficulty(grade ReviewGrade) float64 {
+	return clampDifficulty(f.Weights[4] - float64(grade-3)*f.Weights[5])
+}
+
+// nextDifficulty 更新难度，并向“正常”评分的初始难度回归
+func (f *FSRS) nextDifficulty(difficulty float64, grade ReviewGrade) float64 {
+	next := difficulty - f.Weights[6]*float64(grade-3)
+	return clampDifficulty(f.Weights[7]*f.initDifficulty(GradeGood) + (1-f.Weights[7])*next)
+}
+
+// recallStability 成功回忆后的稳定性
+func (f *FSRS) recallStability(difficulty, stability, r float64, grade ReviewGrade) float64 {
+	modifier := 1.0
+	switch grade {
+	case GradeHard:
+		modifier = f.Weights[15]
+	case GradeEasy:
+		modifier = f.Weights[16]
+	}
+
+	return stability * (1 + math.Exp(f.Weights[8])*(11-difficulty)*math.Pow(stability, -f.Weights[9])*
+		(math.Exp(f.Weights[10]*(1-r))-1)*modifier)
+}
+
+// forgetStability 遗忘后的稳定性，不会超过遗忘前的稳定性
+func (f *FSRS) forgetStability(difficulty, stability, r float64) float64 {
+	next := f.Weights[11] * math.Pow(difficulty, -f.Weights[12]) *
+		(math.Pow(stability+1, f.Weights[13]) - 1) * math.Exp(f.Weights[14]*(1-r))
+	return math.Min(next, stability)
+}
+
+// clampDifficulty 难度限制在 1-10
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, 1), 10)
+}
@@ -2,33 +2,45 @@ package hanbao
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"time"
 )
 
 // TreasureMapService 藏宝图服务
 type TreasureMapService struct {
-	roots        []CharacterRoot
-	vocabularies []Vocabulary
+	repo     ContentRepository
+	coverage *CoverageEstimator
 }
 
-// NewTreasureMapService 创建藏宝图服务
-func NewTreasureMapService() *TreasureMapService {
+// NewTreasureMapService 创建藏宝图服务，coverage 为 nil 时不估算文本覆盖率
+func NewTreasureMapService(repo ContentRepository, coverage *CoverageEstimator) *TreasureMapService {
 	return &TreasureMapService{
-		roots:        CharacterRootsData,
-		vocabularies: VocabularyData,
+		repo:     repo,
+		coverage: coverage,
 	}
 }
 
-// GenerateTreasureMap 生成藏宝图
-func (s *TreasureMapService) GenerateTreasureMap(sessionID string, unlockedRoots []int64) (*TreasureMap, error) {
+// GenerateTreasureMap 根据会话进度生成藏宝图
+func (s *TreasureMapService) GenerateTreasureMap(session *UserSession) (*TreasureMap, error) {
+	unlockedRoots := session.UnlockedRoots
 	if len(unlockedRoots) == 0 {
-		return nil, fmt.Errorf("没有已解锁的字根")
+		return nil, ErrNoUnlockedRoots
+	}
+
+	allRoots, err := s.repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+	allVocabularies, err := s.repo.ListVocabularies()
+	if err != nil {
+		return nil, err
 	}
 
 	// 获取已解锁的字根详情
 	roots := make([]CharacterRoot, 0, len(unlockedRoots))
 	for _, rootID := range unlockedRoots {
-		for _, root := range s.roots {
+		for _, root := range allRoots {
 			if root.ID == rootID {
 				roots = append(roots, root)
 				break
@@ -36,11 +48,17 @@ func (s *TreasureMapService) GenerateTreasureMap(sessionID string, unlockedRoots
 		}
 	}
 
-	// 按字根分组词汇
+	unlocked := make(map[int64]bool, len(unlockedRoots))
+	for _, rootID := range unlockedRoots {
+		unlocked[rootID] = true
+	}
+
+	// 按字根分组词汇，复合词会出现在每个组成字根下，但只统计一次
 	vocabularies := make(map[string][]Vocabulary)
+	counted := make(map[string]bool)
 	totalWords := 0
-	jaWords := 0
-	koWords := 0
+	learnedWords := 0
+	languageWords := make(map[string]int)
 
 	for _, rootID := range unlockedRoots {
 		rootKey := ""
@@ -56,40 +74,46 @@ func (s *TreasureMapService) GenerateTreasureMap(sessionID string, unlockedRoots
 		}
 
 		var rootVocabs []Vocabulary
-		for _, vocab := range s.vocabularies {
-			if vocab.RootID == rootID {
+		for _, vocab := range allVocabularies {
+			if vocab.HasRoot(rootID) {
 				rootVocabs = append(rootVocabs, vocab)
+				if counted[vocab.WordKey()] {
+					continue
+				}
+				counted[vocab.WordKey()] = true
 				totalWords++
-				if vocab.Language == "ja" {
-					jaWords++
-				} else if vocab.Language == "ko" {
-					koWords++
+				if vocab.UnlockedBy(unlocked) {
+					learnedWords++
 				}
+				languageWords[vocab.Language]++
 			}
 		}
 		vocabularies[rootKey] = rootVocabs
 	}
 
 	// 生成连接关系（简化版）
-	connections := s.generateConnections(unlockedRoots)
+	connections := s.generateConnections(unlockedRoots, allRoots)
+	connections = append(connections, s.generateCompoundConnections(unlockedRoots, allRoots, allVocabularies)...)
 
 	// 计算统计数据
 	stats := SessionStats{
-		TotalRoots:     len(roots),
-		UnlockedRoots:  len(unlockedRoots),
+		TotalRoots:     len(allRoots),
+		UnlockedRoots:  len(roots),
 		TotalWords:     totalWords,
-		LearnedWords:   totalWords, // 假设都已学习
-		Accuracy:       85.0,       // 示例准确率
-		AverageTime:    45,         // 示例平均用时
-		CompletionRate: 100.0,      // 完成率
+		LearnedWords:   learnedWords, // 所有组成字根都已解锁的词汇
+		LanguageWords:  languageWords,
+		TextCoverage:   s.coverage.Estimate(unlockedRoots),
+		Accuracy:       session.Accuracy,
+		AverageTime:    averageAnswerTime(session),
+		CompletionRate: completionRate(session),
 	}
 
 	// 获取成就
 	achievements := s.calculateAchievements(stats)
 
 	treasureMap := &TreasureMap{
-		UserID:       "demo_user", // 示例用户ID
-		SessionID:    sessionID,
+		UserID:       session.UserID,
+		SessionID:    session.ID,
 		Roots:        roots,
 		Vocabularies: vocabularies,
 		Connections:  connections,
@@ -100,14 +124,31 @@ func (s *TreasureMapService) GenerateTreasureMap(sessionID string, unlockedRoots
 	return treasureMap, nil
 }
 
+// averageAnswerTime 计算平均每题用时（秒）
+func averageAnswerTime(session *UserSession) int {
+	if session.TotalAnswers == 0 {
+		return 0
+	}
+	return int(session.LastActive.Sub(session.StartTime).Seconds()) / session.TotalAnswers
+}
+
+// completionRate 计算会话关卡完成率，目标关卡数与 GenerateSessionLevels 一致
+func completionRate(session *UserSession) float64 {
+	target := sessionLevelCount(len(session.UnlockedRoots))
+	if target == 0 {
+		return 0
+	}
+	return math.Min(100, float64(len(session.CompletedLevels))/float64(target)*100)
+}
+
 // generateConnections 生成字根连接关系
-func (s *TreasureMapService) generateConnections(unlockedRoots []int64) []Connection {
+func (s *TreasureMapService) generateConnections(unlockedRoots []int64, allRoots []CharacterRoot) []Connection {
 	connections := make([]Connection, 0)
 
 	// 简单的连接逻辑：相同类型的字根连接
 	rootMap := make(map[int64]CharacterRoot)
 	for _, rootID := range unlockedRoots {
-		for _, root := range s.roots {
+		for _, root := range allRoots {
 			if root.ID == rootID {
 				rootMap[rootID] = root
 				break
@@ -137,6 +178,49 @@ func (s *TreasureMapService) generateConnections(unlockedRoots []int64) []Connec
 	return connections
 }
 
+// generateCompoundConnections 为同时出现在一个复合词中的已解锁字根建立连接
+func (s *TreasureMapService) generateCompoundConnections(unlockedRoots []int64, allRoots []CharacterRoot, allVocabularies []Vocabulary) []Connection {
+	connections := make([]Connection, 0)
+
+	unlocked := make(map[int64]bool, len(unlockedRoots))
+	for _, rootID := range unlockedRoots {
+		unlocked[rootID] = true
+	}
+	rootMap := make(map[int64]CharacterRoot, len(allRoots))
+	for _, root := range allRoots {
+		rootMap[root.ID] = root
+	}
+
+	// 每对字根只连接一次，描述中列出共同组成的词
+	type rootPair struct{ from, to int64 }
+	words := make(map[rootPair][]string)
+	pairs := make([]rootPair, 0)
+	for _, vocab := range allVocabularies {
+		ids := vocab.RootIDs()
+		if len(ids) < 2 || !vocab.UnlockedBy(unlocked) {
+			continue
+		}
+		for i := 0; i < len(ids)-1; i++ {
+			pair := rootPair{ids[i], ids[i+1]}
+			if _, ok := words[pair]; !ok {
+				pairs = append(pairs, pair)
+			}
+			words[pair] = append(words[pair], vocab.Word)
+		}
+	}
+
+	for _, pair := range pairs {
+		connections = append(connections, Connection{
+			FromRootID:  pair.from,
+			ToRootID:    pair.to,
+			Type:        "compound",
+			Description: fmt.Sprintf("%s + %s 组成 %s", rootMap[pair.from].Root, rootMap[pair.to].Root, strings.Join(words[pair], "、")),
+		})
+	}
+
+	return connections
+}
+
 // calculateAchievements 计算成就
 func (s *TreasureMapService) calculateAchievements(stats SessionStats) []Achievement {
 	achievements := make([]Achievement, 0)
@@ -186,15 +270,14 @@ func (s *TreasureMapService) GenerateReportText(treasureMap *TreasureMap) string
 	report := fmt.Sprintf(`🎯 15分钟战报
 
 ✅ 已解锁字根：%d个
-✅ 已掌握词汇：日语%d个 + 韩语%d个
+✅ 已掌握词汇：%s
 ✅ 解密准确率：%.1f%%
 🔥 解锁成就：%d个
 
 📊 词根网络预览：
 `,
 		treasureMap.Stats.UnlockedRoots,
-		len(treasureMap.Vocabularies), // 简化为字根数量，实际应该统计词汇
-		len(treasureMap.Vocabularies),
+		formatLanguageWords(treasureMap.Stats.LanguageWords),
 		treasureMap.Stats.Accuracy,
 		len(treasureMap.Achievements),
 	)
@@ -217,6 +300,20 @@ func (s *TreasureMapService) GenerateReportText(treasureMap *TreasureMap) string
 	return report
 }
 
+// formatLanguageWords 按语言列出词汇数量，如 "日语5个 + 韩语4个 + 越南语3个"
+func formatLanguageWords(languageWords map[string]int) string {
+	var parts []string
+	for _, l := range Languages {
+		if count := languageWords[l.Code]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d个", l.Name, count))
+		}
+	}
+	if len(parts) == 0 {
+		return "0个"
+	}
+	return strings.Join(parts, " + ")
+}
+
 // formatVocabSample 格式化词汇示例
 func (s *TreasureMapService) formatVocabSample(vocabs []Vocabulary, maxCount int) string {
 	if len(vocabs) == 0 {
@@ -230,6 +327,8 @@ func (s *TreasureMapService) formatVocabSample(vocabs []Vocabulary, maxCount int
 		vocab := vocabs[i]
 		if vocab.Language == "ja" {
 			result += fmt.Sprintf("%s（%s）", vocab.Word, vocab.Romaji)
+		} else if vocab.Language == "vi" {
+			result += fmt.Sprintf("%s（%s）", vocab.Word, vocab.Chinese)
 		} else {
 			result += fmt.Sprintf("%s（%s）", vocab.Word, vocab.Pronunciation)
 		}
@@ -247,9 +346,14 @@ func (s *TreasureMapService) formatVocabSample(vocabs []Vocabulary, maxCount int
 }
 
 // GetNextRecommendations 获取下一阶段推荐
-func (s *TreasureMapService) GetNextRecommendations(currentRoots []int64) []CharacterRoot {
+func (s *TreasureMapService) GetNextRecommendations(currentRoots []int64) ([]CharacterRoot, error) {
 	recommendations := make([]CharacterRoot, 0)
 
+	allRoots, err := s.repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+
 	// 找到未解锁的字根
 	unlockedMap := make(map[int64]bool)
 	for _, rootID := range currentRoots {
@@ -257,12 +361,12 @@ func (s *TreasureMapService) GetNextRecommendations(currentRoots []int64) []Char
 	}
 
 	// 推荐相同难度或更高一级的字根
-	for _, root := range s.roots {
+	for _, root := range allRoots {
 		if !unlockedMap[root.ID] {
 			// 优先推荐相同难度
 			hasSameDifficulty := false
 			for _, unlockedID := range currentRoots {
-				for _, unlockedRoot := range s.roots {
+				for _, unlockedRoot := range allRoots {
 					if unlockedRoot.ID == unlockedID && unlockedRoot.Difficulty == root.Difficulty {
 						hasSameDifficulty = true
 						break
@@ -282,7 +386,7 @@ func (s *TreasureMapService) GetNextRecommendations(currentRoots []int64) []Char
 		}
 	}
 
-	return recommendations
+	return recommendations, nil
 }
 
 // abs 返回整数的绝对值
@@ -1,12 +1,20 @@
 package hanbao
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 // CharacterRoot 汉字字根
 type CharacterRoot struct {
 	ID          int64     `json:"id" db:"id"`
 	Root        string    `json:"root" db:"root"`               // 字根汉字，如 "电"
 	Pinyin      string    `json:"pinyin" db:"pinyin"`           // 拼音，如 "diàn"
+	MiddleChinese string  `json:"middle_chinese,omitempty" db:"middle_chinese"` // 中古汉语拟音（白一平转写），如 "denH"，用于推测韩语、日语读音
+	Traditional string    `json:"traditional,omitempty" db:"traditional"` // 繁体字，与简体相同时留空，如 "電"
+	Japanese    string    `json:"japanese,omitempty" db:"japanese"`       // 日本新字体，与简体相同时留空，如 "発"
+	Korean      string    `json:"korean,omitempty" db:"korean"`           // 韩国汉字，与简体相同时留空，如 "學"
 	Difficulty  int       `json:"difficulty" db:"difficulty"`   // 难度等级 1-3
 	Tier        int       `json:"tier" db:"tier"`               // 优先级层级 1-3
 	Description string    `json:"description" db:"description"` // 字根描述
@@ -17,11 +25,16 @@ type CharacterRoot struct {
 // Vocabulary 词汇信息
 type Vocabulary struct {
 	ID             int64         `json:"id" db:"id"`
-	RootID         int64         `json:"root_id" db:"root_id"`               // 关联的字根ID
-	Language       string        `json:"language" db:"language"`             // 语言: "ja" 或 "ko"
+	RootID         int64         `json:"root_id" db:"root_id"`               // 主字根ID，完整的组成字根见 Roots
+	Roots          []VocabularyRoot `json:"roots,omitempty" db:"-"`          // 按顺序排列的组成字根，如 電話 → 电(0) + 话(1)
+	Language       string        `json:"language" db:"language"`             // 语言代码，见 Languages，如 "ja"、"ko"、"en"
 	Word           string        `json:"word" db:"word"`                     // 词汇，如 "電話"
 	Romaji         string        `json:"romaji,omitempty" db:"romaji"`       // 日语罗马字，如 "denwa"
-	Pronunciation  string        `json:"pronunciation" db:"pronunciation"`   // 发音，如 "でんわ"
+	Hanja          string        `json:"hanja,omitempty" db:"hanja"`         // 韩语汉字词逐音节对应的汉字，如 전화 → "電話"
+	Chinese        string        `json:"chinese,omitempty" db:"chinese"`     // 用简体字写出的同一个汉字词，如 "电话"，各语言中相同的即为同源词
+	Morphemes      string        `json:"morphemes,omitempty" db:"morphemes"` // 英语等非汉字词的词素及对应汉字的位置，如 "tele:0+phone:1"（电+话）
+	Pronunciation  string        `json:"pronunciation" db:"pronunciation"`   // 发音，日语为假名如 "でんわ"，韩语为按实际发音转写的罗马字如 "jeonhwa"
+	Syllables      []string      `json:"syllables,omitempty" db:"-"`         // 韩语逐音节罗马字（不做音变），与汉字逐音节对照，如 전화 → ["jeon", "hwa"]；由谚文自动生成
 	Meaning        string        `json:"meaning" db:"meaning"`               // 含义，如 "telephone"
 	ReadType       string        `json:"read_type,omitempty" db:"read_type"` // 读音类型: "on" 或 "kun" (日语)
 	Difficulty     int           `json:"difficulty" db:"difficulty"`         // 难度等级
@@ -30,14 +43,126 @@ type Vocabulary struct {
 	UpdatedAt      time.Time     `json:"updated_at" db:"updated_at"`
 }
 
+// VocabularyRoot 词汇的组成字根
+type VocabularyRoot struct {
+	RootID   int64 `json:"root_id" db:"root_id"`
+	Position int   `json:"position" db:"position"` // 字根对应的字在词中的位置（从0开始）
+}
+
+// RootIDs 返回词汇按顺序排列的组成字根ID，未填写 Roots 时只包含主字根
+func (v Vocabulary) RootIDs() []int64 {
+	if len(v.Roots) == 0 {
+		return []int64{v.RootID}
+	}
+
+	ids := make([]int64, len(v.Roots))
+	for i, root := range v.Roots {
+		ids[i] = root.RootID
+	}
+	return ids
+}
+
+// HasRoot 判断词汇是否包含指定字根
+func (v Vocabulary) HasRoot(rootID int64) bool {
+	for _, id := range v.RootIDs() {
+		if id == rootID {
+			return true
+		}
+	}
+	return false
+}
+
+// UnlockedBy 判断词汇的所有组成字根是否都已解锁
+func (v Vocabulary) UnlockedBy(unlocked map[int64]bool) bool {
+	for _, id := range v.RootIDs() {
+		if !unlocked[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// WordKey 词汇的去重键，同一语言的同一个词只统计一次
+func (v Vocabulary) WordKey() string {
+	return v.Language + ":" + v.Word
+}
+
+// HanjaSyllable 韩语汉字词的一个音节及其对应的汉字
+type HanjaSyllable struct {
+	Position int    `json:"position"` // 音节在词中的位置（从0开始）
+	Hangul   string `json:"hangul"`   // 谚文音节，如 "전"
+	Hanja    string `json:"hanja"`    // 对应的汉字，如 "電"
+	Reading  string `json:"reading"`  // 音节的罗马字，如 "jeon"，来自词汇的 Syllables，没有时为空
+	RootID   int64  `json:"root_id"`  // 该位置的组成字根，没有收录时为0
+}
+
+// HanjaSyllables 按音节对齐谚文和汉字，未填写汉字或音节数与汉字数不一致时返回 nil
+func (v Vocabulary) HanjaSyllables() []HanjaSyllable {
+	hangul, hanja := []rune(v.Word), []rune(v.Hanja)
+	if len(hanja) == 0 || len(hangul) != len(hanja) {
+		return nil
+	}
+
+	syllables := make([]HanjaSyllable, len(hangul))
+	for i := range hangul {
+		syllables[i] = HanjaSyllable{Position: i, Hangul: string(hangul[i]), Hanja: string(hanja[i])}
+		if len(v.Syllables) == len(hangul) {
+			syllables[i].Reading = v.Syllables[i]
+		}
+	}
+	for _, root := range v.Roots {
+		if root.Position >= 0 && root.Position < len(syllables) {
+			syllables[root.Position].RootID = root.RootID
+		}
+	}
+	return syllables
+}
+
+// Morpheme 非汉字语言词汇中的一个词素及其对应的汉字
+type Morpheme struct {
+	Text     string `json:"text"`              // 词素，如 "phone"
+	Position int    `json:"position"`          // 对应的汉字在中文词中的位置，没有对应汉字的词缀为 -1
+	Chinese  string `json:"chinese,omitempty"` // 对应的汉字，如 "话"
+	RootID   int64  `json:"root_id,omitempty"` // 该位置的组成字根，没有收录时为0
+}
+
+// SplitMorphemes 解析词素，格式为 词素:位置，多个用 + 连接，词缀可以省略位置，如 "inter:1+nation:0+al"；
+// 位置无效时为 -1
+func (v Vocabulary) SplitMorphemes() []Morpheme {
+	if v.Morphemes == "" {
+		return nil
+	}
+
+	chinese := []rune(v.Chinese)
+	var morphemes []Morpheme
+	for _, part := range strings.Split(v.Morphemes, "+") {
+		text, position, hasPosition := strings.Cut(part, ":")
+		m := Morpheme{Text: strings.TrimSpace(text), Position: -1}
+		if p, err := strconv.Atoi(strings.TrimSpace(position)); hasPosition && err == nil && p >= 0 && p < len(chinese) {
+			m.Position = p
+			m.Chinese = string(chinese[p])
+		}
+		for _, root := range v.Roots {
+			if m.Position >= 0 && root.Position == m.Position {
+				m.RootID = root.RootID
+			}
+		}
+		morphemes = append(morphemes, m)
+	}
+	return morphemes
+}
+
 // UserSession 用户会话
 type UserSession struct {
 	ID            string    `json:"id" db:"id"`
 	UserID        string    `json:"user_id" db:"user_id"`                 // 用户标识（可匿名）
 	UnlockedRoots []int64   `json:"unlocked_roots" db:"unlocked_roots"`   // 已解锁的字根ID列表
 	CompletedLevels []string `json:"completed_levels" db:"completed_levels"` // 已完成的关卡ID
+	AnsweredQuestions []string `json:"answered_questions" db:"answered_questions"` // 已作答的问题，格式为 关卡ID/问题ID，不随关卡答题记录过期
 	Score         int       `json:"score" db:"score"`                     // 总得分
 	Accuracy      float64   `json:"accuracy" db:"accuracy"`               // 准确率
+	TotalAnswers  int       `json:"total_answers" db:"total_answers"`     // 已提交的答案数
+	CorrectAnswers int      `json:"correct_answers" db:"correct_answers"` // 答对的答案数
 	StartTime     time.Time `json:"start_time" db:"start_time"`
 	LastActive    time.Time `json:"last_active" db:"last_active"`
 	Status        string    `json:"status" db:"status"`                   // 会话状态: "active", "completed"
@@ -45,6 +170,14 @@ type UserSession struct {
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// LearnerID 复习记录使用的学习者标识，匿名会话使用会话ID
+func (s *UserSession) LearnerID() string {
+	if s.UserID != "" {
+		return s.UserID
+	}
+	return s.ID
+}
+
 // Level 关卡
 type Level struct {
 	ID          string `json:"id" db:"id"`
@@ -56,6 +189,7 @@ type Level struct {
 	TimeLimit   int    `json:"time_limit" db:"time_limit"`   // 时间限制（秒）
 	Questions   []Question `json:"questions" db:"questions"` // 问题列表
 	Reward      Reward  `json:"reward" db:"reward"`          // 奖励
+	AnswerToken string `json:"answer_token,omitempty" db:"-"` // 加密的答案令牌，仅无状态模式下生成
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -65,9 +199,14 @@ type Question struct {
 	Type        string   `json:"type"`        // 问题类型: "multiple_choice", "text_input", "audio_match"
 	Content     string   `json:"content"`     // 问题内容
 	Options     []string `json:"options,omitempty"` // 选项（选择题）
+	Matching    *MatchingGrid `json:"matching,omitempty"` // 连线网格（连线题）
 	CorrectAnswer string `json:"correct_answer"` // 正确答案
 	Hint        string   `json:"hint,omitempty"` // 提示
 	Explanation string   `json:"explanation"` // 解释
+	Score       int      `json:"score"`       // 本题分值
+	VocabularyIDs []int64 `json:"vocabulary_ids,omitempty"` // 题目涉及的词汇，答题结果计入这些词汇的复习记录
+	SoundRules  []string `json:"sound_rules,omitempty"` // 题目涉及的读音对应规律ID，答题后在结果中给出完整说明
+	RuleLanguage string  `json:"rule_language,omitempty"` // 题目考查的语言，读音规律的示例只给出该语言的读音，避免泄露其他语言题目的答案
 }
 
 // Reward 奖励
@@ -124,6 +263,8 @@ type SessionStats struct {
 	UnlockedRoots  int     `json:"unlocked_roots"`   // 已解锁字根数
 	TotalWords     int     `json:"total_words"`      // 总词汇数
 	LearnedWords   int     `json:"learned_words"`    // 已学习词汇数
+	LanguageWords  map[string]int `json:"language_words"` // 各语言的词汇数，键为语言代码
+	TextCoverage   []TextCoverage `json:"text_coverage,omitempty"` // 按词频估算的各语言文本覆盖率，没有加载词频表时为空
 	Accuracy       float64 `json:"accuracy"`         // 准确率
 	AverageTime    int     `json:"average_time"`     // 平均用时（秒）
 	CompletionRate float64 `json:"completion_rate"`  // 完成率
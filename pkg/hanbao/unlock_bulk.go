@@ -0,0 +1,206 @@
+package hanbao
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// 批量分析的事件类型
+const (
+	BulkEventRoot     = "root"     // 发现新的字根
+	BulkEventProgress = "progress" // 每分析 bulkProgressLines 行报告一次进度
+	BulkEventResult   = "result"   // 分析完成，附带完整结果
+)
+
+const (
+	bulkProgressLines = 100 // 进度事件的间隔行数
+	bulkSampleWords   = 10  // 每个字根最多列出的来源词数
+)
+
+// BulkEvent 批量分析过程中的事件，用于流式返回结果
+type BulkEvent struct {
+	Type   string            `json:"type"`             // 见 BulkEventRoot 等
+	Line   int               `json:"line"`             // 当前分析到的行号，从1开始
+	Words  int               `json:"words"`            // 已分析的词数
+	Word   string            `json:"word,omitempty"`   // 发现字根的词，仅 root 事件
+	Root   *CharacterRoot    `json:"root,omitempty"`   // 新发现的字根，仅 root 事件
+	Result *BulkUnlockResult `json:"result,omitempty"` // 完整结果，仅 result 事件
+}
+
+// BulkUnlockResult 批量分析结果
+type BulkUnlockResult struct {
+	TotalWords     int                `json:"total_words"`             // 分词后的总词数（重复出现的词分别计数）
+	UniqueWords    int                `json:"unique_words"`            // 不同的词数
+	MatchedWords   int                `json:"matched_words"`           // 包含字根的词数（重复出现的词分别计数）
+	RootCount      int                `json:"root_count"`              // 发现的字根数
+	Roots          []RootFrequency    `json:"roots"`                   // 字根按出现次数从多到少排列
+	Coverage       []LanguageCoverage `json:"coverage"`                // 各语言词汇的解锁比例，按 Languages 的顺序排列
+	TextCoverage   []TextCoverage     `json:"text_coverage,omitempty"` // 按词频估算的各语言文本覆盖率，没有加载词频表时为空
+	UnmatchedWords []string           `json:"unmatched_words"`         // 不包含任何字根的词，按第一次出现的顺序排列
+}
+
+// RootFrequency 字根在输入中的出现频率
+type RootFrequency struct {
+	Root      CharacterRoot `json:"root"`
+	Count     int           `json:"count"`      // 包含该字根的词出现的次数
+	WordCount int           `json:"word_count"` // 包含该字根的不同词数
+	Words     []string      `json:"words"`      // 包含该字根的词，最多 bulkSampleWords 个
+}
+
+// LanguageCoverage 发现的字根能解锁的某种语言词汇比例
+type LanguageCoverage struct {
+	Language        string  `json:"language"`
+	Name            string  `json:"name"`
+	UnlockableWords int     `json:"unlockable_words"` // 可解锁的词汇数
+	TotalWords      int     `json:"total_words"`      // 该语言的词汇总数
+	Percent         float64 `json:"percent"`          // 可解锁比例（0-100）
+}
+
+// AnalyzeBulk 批量分析一段文本或一个文件（如整课的词汇表），逐行分词并统计字根频率、
+// 各语言的解锁比例和没有贡献字根的词；emit 不为 nil 时在分析过程中发送事件，返回错误时中止分析
+func (s *UnlockCeremonyService) AnalyzeBulk(r io.Reader, emit func(BulkEvent) error) (*BulkUnlockResult, error) {
+	if emit == nil {
+		emit = func(BulkEvent) error { return nil }
+	}
+
+	vocabularies, err := s.repo.ListVocabularies()
+	if err != nil {
+		return nil, err
+	}
+	allRoots, err := s.repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+	rootsByID := make(map[int64]CharacterRoot, len(allRoots))
+	for _, root := range allRoots {
+		rootsByID[root.ID] = root
+	}
+	segmenter := NewSegmenter(allRoots, vocabularies)
+
+	maxWords := s.limits.bulkMaxWords()
+	result := &BulkUnlockResult{}
+	frequencies := make(map[int64]*RootFrequency)
+	var order []int64
+	seenWords := make(map[string]bool)
+	type wordRoot struct {
+		word   string
+		rootID int64
+	}
+	wordRoots := make(map[wordRoot]bool)
+
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		text, readErr := reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return nil, readErr
+		}
+
+		for _, segment := range segmenter.Segment(text) {
+			result.TotalWords++
+			if result.TotalWords > maxWords {
+				return nil, ErrTooManyWords.WithDetails(map[string]any{"max": maxWords, "count": result.TotalWords})
+			}
+
+			key := segmentKey(segment.Text)
+			if !seenWords[key] {
+				seenWords[key] = true
+				result.UniqueWords++
+				if len(segment.RootIDs) == 0 {
+					result.UnmatchedWords = append(result.UnmatchedWords, segment.Text)
+				}
+			}
+			if len(segment.RootIDs) > 0 {
+				result.MatchedWords++
+			}
+
+			for _, rootID := range segment.RootIDs {
+				root, ok := rootsByID[rootID]
+				if !ok {
+					continue
+				}
+				freq, exists := frequencies[rootID]
+				if !exists {
+					freq = &RootFrequency{Root: root}
+					frequencies[rootID] = freq
+					order = append(order, rootID)
+					if err := emit(BulkEvent{Type: BulkEventRoot, Line: line, Words: result.TotalWords, Word: segment.Text, Root: &root}); err != nil {
+						return nil, err
+					}
+				}
+				freq.Count++
+				if !wordRoots[wordRoot{key, rootID}] {
+					wordRoots[wordRoot{key, rootID}] = true
+					freq.WordCount++
+					if len(freq.Words) < bulkSampleWords {
+						freq.Words = append(freq.Words, segment.Text)
+					}
+				}
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if line%bulkProgressLines == 0 {
+			if err := emit(BulkEvent{Type: BulkEventProgress, Line: line, Words: result.TotalWords}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if result.TotalWords == 0 {
+		return nil, ErrNoWords
+	}
+
+	// 字根按出现次数排列，次数相同时按第一次出现的顺序
+	result.Roots = make([]RootFrequency, len(order))
+	for i, rootID := range order {
+		result.Roots[i] = *frequencies[rootID]
+	}
+	sort.SliceStable(result.Roots, func(i, j int) bool {
+		return result.Roots[i].Count > result.Roots[j].Count
+	})
+	result.RootCount = len(result.Roots)
+
+	unlocked, total := countUnlockableWords(vocabularies, func(rootID int64) bool {
+		_, exists := frequencies[rootID]
+		return exists
+	})
+	result.Coverage = languageCoverage(unlocked, total)
+	result.TextCoverage = s.coverage.Estimate(order)
+	if result.UnmatchedWords == nil {
+		result.UnmatchedWords = []string{}
+	}
+
+	if err := emit(BulkEvent{Type: BulkEventResult, Words: result.TotalWords, Result: result}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AnalyzeBulkText 批量分析文本，每个词语或句子占一行
+func (s *UnlockCeremonyService) AnalyzeBulkText(words []string, emit func(BulkEvent) error) (*BulkUnlockResult, error) {
+	return s.AnalyzeBulk(strings.NewReader(strings.Join(words, "\n")), emit)
+}
+
+// languageCoverage 各语言的解锁比例，按 Languages 的顺序排列，没有词汇的语言不计入
+func languageCoverage(unlocked, total map[string]int) []LanguageCoverage {
+	coverage := make([]LanguageCoverage, 0, len(total))
+	for _, language := range Languages {
+		count := total[language.Code]
+		if count == 0 {
+			continue
+		}
+		coverage = append(coverage, LanguageCoverage{
+			Language:        language.Code,
+			Name:            language.Name,
+			UnlockableWords: unlocked[language.Code],
+			TotalWords:      count,
+			Percent:         float64(unlocked[language.Code]) / float64(count) * 100,
+		})
+	}
+	return coverage
+}
@@ -2,125 +2,200 @@ package hanbao
 
 import (
 	"fmt"
-	"unicode/utf8"
+	"strings"
 )
 
 // UnlockCeremonyService 词根解锁仪式服务
 type UnlockCeremonyService struct {
-	roots      []CharacterRoot
-	vocabularies []Vocabulary
+	repo     ContentRepository
+	limits   UnlockLimits
+	coverage *CoverageEstimator
 }
 
-// NewUnlockCeremonyService 创建解锁仪式服务
-func NewUnlockCeremonyService() *UnlockCeremonyService {
+// NewUnlockCeremonyService 创建解锁仪式服务，coverage 为 nil 时不估算文本覆盖率
+func NewUnlockCeremonyService(repo ContentRepository, limits UnlockLimits, coverage *CoverageEstimator) *UnlockCeremonyService {
 	return &UnlockCeremonyService{
-		roots:        CharacterRootsData,
-		vocabularies: VocabularyData,
+		repo:     repo,
+		limits:   limits,
+		coverage: coverage,
 	}
 }
 
+// 未配置时的解锁输入上限
+const (
+	DefaultUnlockMaxWords = 5    // 每次解锁最多输入的词语数，见 unlockWordCount
+	DefaultBulkMaxWords   = 5000 // 批量分析最多包含的词数（分词后）
+)
+
+// UnlockLimits 解锁仪式的输入上限，可以按客户端类型分别配置
+type UnlockLimits struct {
+	MaxWords     int            // 默认上限，不大于0时使用 DefaultUnlockMaxWords
+	Clients      map[string]int // 客户端类型对应的上限，如 {"teacher": 200}
+	BulkMaxWords int            // 批量分析的上限，不大于0时使用 DefaultBulkMaxWords
+}
+
+// MaxWordsFor 指定客户端类型每次最多输入的词语数，未单独配置的客户端使用默认上限
+func (l UnlockLimits) MaxWordsFor(client string) int {
+	if max, ok := l.Clients[client]; ok && max > 0 {
+		return max
+	}
+	if l.MaxWords > 0 {
+		return l.MaxWords
+	}
+	return DefaultUnlockMaxWords
+}
+
+// bulkMaxWords 批量分析最多包含的词数
+func (l UnlockLimits) bulkMaxWords() int {
+	if l.BulkMaxWords > 0 {
+		return l.BulkMaxWords
+	}
+	return DefaultBulkMaxWords
+}
+
 // UnlockRequest 解锁请求
 type UnlockRequest struct {
-	Words []string `json:"words"` // 用户输入的词语，如 ["电话", "发现", "图书馆"]
+	Words  []string `json:"words"`            // 用户输入的词语或句子，如 ["电话", "发现", "图书馆"]、["我在大学学习"]
+	Client string   `json:"client,omitempty"` // 客户端类型，决定输入上限，见 UnlockLimits；应由服务端根据认证信息确定，不能直接取自客户端
 }
 
 // UnlockResult 解锁结果
 type UnlockResult struct {
 	InputWords     []string           `json:"input_words"`
-	DetectedRoots  []CharacterRoot    `json:"detected_roots"`
+	Segments       []Segment          `json:"segments"`       // 输入分词后的结果
+	DetectedRoots  []CharacterRoot    `json:"detected_roots"` // 按出现顺序排列
+	RootSources    []RootSource       `json:"root_sources"`   // 每个字根来自哪些词，与 DetectedRoots 顺序一致
 	RootCount      int                `json:"root_count"`
 	UnlockableWords int               `json:"unlockable_words"` // 可解锁的词汇总数
 	WordBreakdown  map[string]int     `json:"word_breakdown"`   // 按语言分组的词汇数
+	TextCoverage   []TextCoverage     `json:"text_coverage,omitempty"` // 按词频估算的各语言文本覆盖率，没有加载词频表时为空
 	Insights       []string           `json:"insights"`         // AI洞察
 }
 
-// AnalyzeWords 分析用户输入的词语
+// RootSource 字根的来源
+type RootSource struct {
+	RootID int64    `json:"root_id"`
+	Root   string   `json:"root"`
+	Words  []string `json:"words"` // 包含该字根的词（分词结果）
+}
+
+// unlockWordCount 一个输入项计入上限的词数：按词典识别出的词数，至少为1。
+// 没有收录的汉字会逐字切分，按分词结果计数时“发现”这样的普通词语会算成多个词；
+// 按识别出的词计数则在一个输入项里写整句话也不能绕过上限
+func unlockWordCount(segments []Segment) int {
+	known := 0
+	for _, segment := range segments {
+		if segment.Known {
+			known++
+		}
+	}
+	return max(known, 1)
+}
+
+// AnalyzeWords 分析用户输入的词语，词语也可以是完整的句子，按词典分词后提取字根
 func (s *UnlockCeremonyService) AnalyzeWords(req UnlockRequest) (*UnlockResult, error) {
 	if len(req.Words) == 0 {
-		return nil, fmt.Errorf("至少需要输入一个词语")
+		return nil, ErrNoWords
 	}
 
-	if len(req.Words) > 5 {
-		return nil, fmt.Errorf("最多只能输入5个词语")
+	vocabularies, err := s.repo.ListVocabularies()
+	if err != nil {
+		return nil, err
+	}
+	allRoots, err := s.repo.ListRoots()
+	if err != nil {
+		return nil, err
+	}
+	rootsByID := make(map[int64]CharacterRoot, len(allRoots))
+	for _, root := range allRoots {
+		rootsByID[root.ID] = root
 	}
 
-	// 提取所有字根
-	detectedRoots := make(map[int64]CharacterRoot)
-	allChars := make([]string, 0)
-
-	// 收集所有输入词语中的汉字
+	// 分词并按出现顺序收集字根，记录每个字根来自哪些词
+	segmenter := NewSegmenter(allRoots, vocabularies)
+	roots := make([]CharacterRoot, 0)
+	sources := make(map[int64]*RootSource)
+	maxWords := s.limits.MaxWordsFor(req.Client)
+	var segments []Segment
+	count := 0
 	for _, word := range req.Words {
-		chars := s.splitIntoChars(word)
-		allChars = append(allChars, chars...)
+		wordSegments := segmenter.Segment(word)
+		count += unlockWordCount(wordSegments)
+		if count > maxWords {
+			return nil, ErrTooManyWords.WithDetails(map[string]any{"max": maxWords, "count": count, "items": len(req.Words)})
+		}
+		segments = append(segments, wordSegments...)
 	}
-
-	// 匹配字根
-	for _, char := range allChars {
-		for _, root := range s.roots {
-			if root.Root == char {
-				detectedRoots[root.ID] = root
-				break
+	for _, segment := range segments {
+		for _, rootID := range segment.RootIDs {
+			root, ok := rootsByID[rootID]
+			if !ok {
+				continue
+			}
+			if _, exists := sources[rootID]; !exists {
+				roots = append(roots, root)
+				sources[rootID] = &RootSource{RootID: rootID, Root: root.Root}
 			}
+			sources[rootID].Words = appendUnique(sources[rootID].Words, segment.Text)
 		}
 	}
-
-	// 转换为切片
-	roots := make([]CharacterRoot, 0, len(detectedRoots))
-	for _, root := range detectedRoots {
-		roots = append(roots, root)
+	rootSources := make([]RootSource, len(roots))
+	for i, root := range roots {
+		rootSources[i] = *sources[root.ID]
 	}
 
 	// 计算可解锁的词汇
-	wordBreakdown := make(map[string]int)
-	unlockableWords := 0
+	wordBreakdown, _ := countUnlockableWords(vocabularies, func(rootID int64) bool {
+		_, exists := sources[rootID]
+		return exists
+	})
+	unlockableWords := totalBreakdownWords(wordBreakdown)
 
-	for _, vocab := range s.vocabularies {
-		if _, exists := detectedRoots[vocab.RootID]; exists {
-			wordBreakdown[vocab.Language]++
-			unlockableWords++
-		}
+	// 按词频估算文本覆盖率
+	rootIDs := make([]int64, len(roots))
+	for i, root := range roots {
+		rootIDs[i] = root.ID
 	}
+	textCoverage := s.coverage.Estimate(rootIDs)
 
 	// 生成AI洞察
-	insights := s.generateInsights(roots, wordBreakdown)
+	insights := s.generateInsights(roots, wordBreakdown, textCoverage)
 
 	return &UnlockResult{
 		InputWords:     req.Words,
+		Segments:       segments,
 		DetectedRoots:  roots,
+		RootSources:    rootSources,
 		RootCount:      len(roots),
 		UnlockableWords: unlockableWords,
 		WordBreakdown:  wordBreakdown,
+		TextCoverage:   textCoverage,
 		Insights:       insights,
 	}, nil
 }
 
-// splitIntoChars 将中文词语拆分为单个汉字
-func (s *UnlockCeremonyService) splitIntoChars(word string) []string {
-	var chars []string
-	for _, r := range word {
-		if utf8.RuneLen(r) > 1 { // 中文字符
-			chars = append(chars, string(r))
-		}
-	}
-	return chars
-}
-
 // generateInsights 生成AI洞察
-func (s *UnlockCeremonyService) generateInsights(roots []CharacterRoot, wordBreakdown map[string]int) []string {
+func (s *UnlockCeremonyService) generateInsights(roots []CharacterRoot, wordBreakdown map[string]int, textCoverage []TextCoverage) []string {
 	insights := make([]string, 0)
 
 	// 基础洞察
 	if len(roots) > 0 {
 		insights = append(insights, fmt.Sprintf("你输入的%d个词中，有%d个汉字字根！", len(roots), len(roots)))
-		insights = append(insights, fmt.Sprintf("这%d个字根能帮你解锁至少%d个日韩语词汇", len(roots), wordBreakdown["ja"]+wordBreakdown["ko"]))
+		insights = append(insights, fmt.Sprintf("这%d个字根能帮你解锁至少%d个%s词汇", len(roots), totalBreakdownWords(wordBreakdown), languageNames(wordBreakdown)))
 	}
 
 	// 语言分布洞察
-	if jaCount, ok := wordBreakdown["ja"]; ok && jaCount > 0 {
-		insights = append(insights, fmt.Sprintf("日语词汇：%d个（包括音读和训读）", jaCount))
+	for _, language := range Languages {
+		if count := wordBreakdown[language.Code]; count > 0 {
+			insights = append(insights, fmt.Sprintf("%s词汇：%d个（%s）", language.Name, count, language.Note))
+		}
 	}
-	if koCount, ok := wordBreakdown["ko"]; ok && koCount > 0 {
-		insights = append(insights, fmt.Sprintf("韩语词汇：%d个（汉字词）", koCount))
+
+	// 文本覆盖率洞察
+	for _, c := range textCoverage {
+		if c.Words > 0 {
+			insights = append(insights, fmt.Sprintf("按词频估算，这些字根出现在%d个常用%s词中，覆盖日常%s文本的%.1f%%", c.Words, c.Name, c.Name, c.Percent))
+		}
 	}
 
 	// 难度分析
@@ -140,7 +215,7 @@ func (s *UnlockCeremonyService) generateInsights(roots []CharacterRoot, wordBrea
 	}
 
 	// 任务建议
-	totalWords := wordBreakdown["ja"] + wordBreakdown["ko"]
+	totalWords := totalBreakdownWords(wordBreakdown)
 	if totalWords > 0 {
 		insights = append(insights, fmt.Sprintf("今日任务：通过解谜，解锁其中%d个词汇", min(20, totalWords)))
 	}
@@ -148,6 +223,49 @@ func (s *UnlockCeremonyService) generateInsights(roots []CharacterRoot, wordBrea
 	return insights
 }
 
+// countUnlockableWords 按语言统计包含已发现字根的词汇数和词汇总数，复合词包含多个字根，同一个词只统计一次
+func countUnlockableWords(vocabularies []Vocabulary, detected func(rootID int64) bool) (unlocked, total map[string]int) {
+	unlocked, total = make(map[string]int), make(map[string]int)
+	seen, counted := make(map[string]bool), make(map[string]bool)
+	for _, vocab := range vocabularies {
+		if !seen[vocab.WordKey()] {
+			seen[vocab.WordKey()] = true
+			total[vocab.Language]++
+		}
+		if counted[vocab.WordKey()] {
+			continue
+		}
+		for _, rootID := range vocab.RootIDs() {
+			if detected(rootID) {
+				counted[vocab.WordKey()] = true
+				unlocked[vocab.Language]++
+				break
+			}
+		}
+	}
+	return unlocked, total
+}
+
+// totalBreakdownWords 各语言词汇数之和
+func totalBreakdownWords(wordBreakdown map[string]int) int {
+	total := 0
+	for _, count := range wordBreakdown {
+		total += count
+	}
+	return total
+}
+
+// languageNames 有词汇的语言名称，按 Languages 的顺序用顿号连接，如 "日语、韩语、英语"
+func languageNames(wordBreakdown map[string]int) string {
+	var names []string
+	for _, language := range Languages {
+		if wordBreakdown[language.Code] > 0 {
+			names = append(names, language.Name)
+		}
+	}
+	return strings.Join(names, "、")
+}
+
 // min 返回两个整数中的较小值
 func min(a, b int) int {
 	if a < b {
@@ -157,33 +275,16 @@ func min(a, b int) int {
 }
 
 // GetRootByID 根据ID获取字根
-func (s *UnlockCeremonyService) GetRootByID(rootID int64) *CharacterRoot {
-	for _, root := range s.roots {
-		if root.ID == rootID {
-			return &root
-		}
-	}
-	return nil
+func (s *UnlockCeremonyService) GetRootByID(rootID int64) (*CharacterRoot, error) {
+	return s.repo.GetRootByID(rootID)
 }
 
 // GetVocabulariesByRoot 获取指定字根的所有词汇
-func (s *UnlockCeremonyService) GetVocabulariesByRoot(rootID int64) []Vocabulary {
-	var result []Vocabulary
-	for _, vocab := range s.vocabularies {
-		if vocab.RootID == rootID {
-			result = append(result, vocab)
-		}
-	}
-	return result
+func (s *UnlockCeremonyService) GetVocabulariesByRoot(rootID int64) ([]Vocabulary, error) {
+	return s.repo.GetVocabulariesByRoot(rootID)
 }
 
 // GetVocabulariesByLanguage 获取指定语言的所有词汇
-func (s *UnlockCeremonyService) GetVocabulariesByLanguage(language string) []Vocabulary {
-	var result []Vocabulary
-	for _, vocab := range s.vocabularies {
-		if vocab.Language == language {
-			result = append(result, vocab)
-		}
-	}
-	return result
+func (s *UnlockCeremonyService) GetVocabulariesByLanguage(language string) ([]Vocabulary, error) {
+	return s.repo.GetVocabulariesByLanguage(language)
 }
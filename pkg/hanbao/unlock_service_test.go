@@ -0,0 +1,46 @@
+package hanbao
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestAnalyzeWordsDefaultLimit README 和网页中的示例输入在默认上限下可以解锁，超过上限时报告计入上限的词数
+func TestAnalyzeWordsDefaultLimit(t *testing.T) {
+	s := NewUnlockCeremonyService(NewDefaultContentRepository(), UnlockLimits{}, nil)
+
+	tests := []struct {
+		name    string
+		words   []string
+		wantErr bool
+		count   int
+	}{
+		{name: "documented example", words: []string{"电话", "发现", "图书馆"}},
+		{name: "sentence", words: []string{"我在大学学习"}},
+		{name: "too many items", words: []string{"电话", "发现", "图书馆", "学生", "国家", "现在"}, wantErr: true, count: 6},
+		{name: "too many words in one item", words: []string{"电话学生大学学校电车电池"}, wantErr: true, count: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.AnalyzeWords(UnlockRequest{Words: tt.words})
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("AnalyzeWords(%v) error = %v", tt.words, err)
+				}
+				if result.RootCount == 0 {
+					t.Errorf("AnalyzeWords(%v) found no roots", tt.words)
+				}
+				return
+			}
+
+			var e *Error
+			if !errors.As(err, &e) || !errors.Is(err, ErrTooManyWords) {
+				t.Fatalf("AnalyzeWords(%v) error = %v, want %v", tt.words, err, ErrTooManyWords)
+			}
+			details := e.Details
+			if details["count"] != tt.count || details["max"] != DefaultUnlockMaxWords {
+				t.Errorf("AnalyzeWords(%v) details = %v, want count %d, max %d", tt.words, details, tt.count, DefaultUnlockMaxWords)
+			}
+		})
+	}
+}
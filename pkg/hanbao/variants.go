@@ -0,0 +1,82 @@
+package hanbao
+
+// 字形所属的地区
+const (
+	VariantSimplified  = "zh-Hans" // 简体字，即字根本身
+	VariantTraditional = "zh-Hant" // 繁体字（台湾、香港）
+	VariantJapanese    = "ja"      // 日本新字体
+	VariantKorean      = "ko"      // 韩国汉字
+)
+
+// variantRegions 字形地区的显示顺序和名称
+var variantRegions = []struct {
+	Region string
+	Name   string
+}{
+	{VariantSimplified, "简体字"},
+	{VariantTraditional, "繁体字"},
+	{VariantJapanese, "日本新字体"},
+	{VariantKorean, "韩国汉字"},
+}
+
+// CharacterVariant 字根在某个地区的写法
+type CharacterVariant struct {
+	Region string `json:"region"` // 见 VariantSimplified 等
+	Name   string `json:"name"`   // 地区写法的名称，如 "日本新字体"
+	Form   string `json:"form"`   // 字形，如 "発"
+}
+
+// Form 字根在指定地区的写法，没有单独标注时与简体字相同
+func (r CharacterRoot) Form(region string) string {
+	var form string
+	switch region {
+	case VariantTraditional:
+		form = r.Traditional
+	case VariantJapanese:
+		form = r.Japanese
+	case VariantKorean:
+		form = r.Korean
+	}
+	if form == "" {
+		return r.Root
+	}
+	return form
+}
+
+// Variants 字根在各地区的写法，按简体、繁体、日本、韩国的顺序排列
+func (r CharacterRoot) Variants() []CharacterVariant {
+	variants := make([]CharacterVariant, len(variantRegions))
+	for i, region := range variantRegions {
+		variants[i] = CharacterVariant{Region: region.Region, Name: region.Name, Form: r.Form(region.Region)}
+	}
+	return variants
+}
+
+// Forms 字根所有不同的字形，简体字在最前，如 发 → [发 發 発]
+func (r CharacterRoot) Forms() []string {
+	var forms []string
+	for _, v := range r.Variants() {
+		forms = appendUnique(forms, v.Form)
+	}
+	return forms
+}
+
+// HasForm 字符是否为字根的任一写法
+func (r CharacterRoot) HasForm(char string) bool {
+	for _, form := range r.Forms() {
+		if form == char {
+			return true
+		}
+	}
+	return false
+}
+
+// VariantName 字形地区的名称
+func VariantName(region string) string {
+	for _, r := range variantRegions {
+		if r.Region == region {
+			return r.Name
+		}
+	}
+	return region
+}
@@ -0,0 +1,101 @@
+package hanbao
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 越南语的声调符号（组合字符），依次为 玄声、锐声、跌声、问声、重声
+var vietnameseToneMarks = []rune{'\u0300', '\u0301', '\u0303', '\u0309', '\u0323'}
+
+// HanVietSyllable 越南语汉越词的一个音节及其对应的汉字
+type HanVietSyllable struct {
+	Position int    `json:"position"` // 音节在词中的位置（从0开始）
+	Syllable string `json:"syllable"` // 汉越音节，如 "thoại"
+	Chinese  string `json:"chinese"`  // 对应的汉字（简体），如 "话"
+	RootID   int64  `json:"root_id"`  // 该位置的组成字根，没有收录时为0
+}
+
+// HanVietSyllables 按音节对齐越南语汉越词和中文词，越南语音节以空格分隔；
+// 不是越南语、未填写中文词或音节数与字数不一致时返回 nil
+func (v Vocabulary) HanVietSyllables() []HanVietSyllable {
+	if v.Language != "vi" {
+		return nil
+	}
+	syllables, chinese := strings.Fields(norm.NFC.String(v.Word)), []rune(v.Chinese)
+	if len(chinese) == 0 || len(syllables) != len(chinese) {
+		return nil
+	}
+
+	result := make([]HanVietSyllable, len(syllables))
+	for i := range syllables {
+		result[i] = HanVietSyllable{Position: i, Syllable: strings.ToLower(syllables[i]), Chinese: string(chinese[i])}
+	}
+	for _, root := range v.Roots {
+		if root.Position >= 0 && root.Position < len(result) {
+			result[root.Position].RootID = root.RootID
+		}
+	}
+	return result
+}
+
+// foldDiacritics 去掉拉丁字母上的声调和元音符号并转为小写，用于不区分附加符号的搜索，如 "Điện thoại" → "dien thoai"；
+// 假名的浊音符号、谚文的收音等其他文字的组合部分保留，结果重新组合为 NFC，避免 が 匹配 か、학 匹配 하
+func foldDiacritics(s string) string {
+	var b strings.Builder
+	latin := false // 当前组合字符所属的基本字符是否为拉丁字母
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			if latin {
+				continue
+			}
+		case r == 'đ' || r == 'Đ':
+			r, latin = 'd', true
+		default:
+			latin = unicode.Is(unicode.Latin, r)
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.Join(strings.Fields(norm.NFC.String(b.String())), " ")
+}
+
+// vietnameseToneVariants 把音节的声调换成其他声调（含无声调的平声），是越南语读音题最常见的混淆；
+// 平声音节的声调位置由元音决定，不生成变体
+func vietnameseToneVariants(syllable string) []string {
+	decomposed := []rune(norm.NFD.String(syllable))
+	at := -1
+	for i, r := range decomposed {
+		for _, mark := range vietnameseToneMarks {
+			if r == mark {
+				at = i
+			}
+		}
+	}
+	if at < 0 {
+		return nil
+	}
+
+	// 去掉原声调后，新声调放在元音的其他符号之后（如 ê + ◌̀ → ề），NFC 才能组合成正确的字符
+	base := append(append([]rune{}, decomposed[:at]...), decomposed[at+1:]...)
+	end := at
+	for end < len(base) && unicode.Is(unicode.Mn, base[end]) {
+		end++
+	}
+
+	var variants []string
+	for _, mark := range append([]rune{0}, vietnameseToneMarks...) {
+		if mark == decomposed[at] {
+			continue
+		}
+		variant := append([]rune{}, base[:end]...)
+		if mark != 0 {
+			variant = append(variant, mark)
+		}
+		variant = append(variant, base[end:]...)
+		variants = append(variants, norm.NFC.String(string(variant)))
+	}
+	return variants
+}
@@ -0,0 +1,84 @@
+package hanbao
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 搜索匹配程度，数值越小越靠前
+const (
+	searchMatchExact    = iota // 词汇或汉字写法完全一致
+	searchMatchContains        // 带附加符号的原文包含查询
+	searchMatchFolded          // 去掉声调和附加符号后包含查询，如 "dien thoai" 匹配 "điện thoại"
+)
+
+// SearchVocabularies 按词汇、罗马字、发音、汉字写法和释义搜索词汇，language 为空时搜索所有语言；
+// 查询和词汇都按 NFC 规范化，组合字符和预组合字符输入的越南语可以互相匹配，不带声调的输入也能找到结果
+func SearchVocabularies(repo ContentRepository, query, language string, limit int) ([]Vocabulary, error) {
+	query = norm.NFC.String(strings.ToLower(strings.TrimSpace(query)))
+	folded := foldDiacritics(query)
+	if folded == "" {
+		return nil, nil
+	}
+
+	var vocabs []Vocabulary
+	var err error
+	if language == "" {
+		vocabs, err = repo.ListVocabularies()
+	} else {
+		vocabs, err = repo.GetVocabulariesByLanguage(language)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		vocab Vocabulary
+		rank  int
+	}
+	var matches []match
+	for _, vocab := range vocabs {
+		if rank, ok := vocabularySearchRank(vocab, query, folded); ok {
+			matches = append(matches, match{vocab: vocab, rank: rank})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].vocab.ID < matches[j].vocab.ID
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	result := make([]Vocabulary, len(matches))
+	for i, m := range matches {
+		result[i] = m.vocab
+	}
+	return result, nil
+}
+
+// vocabularySearchRank 词汇与查询的匹配程度，query 已规范化为 NFC 小写，folded 为去掉附加符号的形式
+func vocabularySearchRank(vocab Vocabulary, query, folded string) (int, bool) {
+	word := norm.NFC.String(strings.ToLower(vocab.Word))
+	if word == query || vocab.Chinese == query || vocab.Hanja == query {
+		return searchMatchExact, true
+	}
+
+	fields := []string{word, strings.ToLower(vocab.Romaji), strings.ToLower(vocab.Pronunciation),
+		vocab.Chinese, vocab.Hanja, strings.ToLower(vocab.Meaning)}
+	for _, field := range fields {
+		if field != "" && strings.Contains(norm.NFC.String(field), query) {
+			return searchMatchContains, true
+		}
+	}
+	for _, field := range fields {
+		if field != "" && strings.Contains(foldDiacritics(field), folded) {
+			return searchMatchFolded, true
+		}
+	}
+	return 0, false
+}